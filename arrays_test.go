@@ -0,0 +1,89 @@
+package env
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_arrays(t *testing.T) {
+	type TestStruct struct {
+		Weights   [3]float64       `env:"WEIGHTS"`
+		Flags     [2]bool          `env:"FLAGS"`
+		Names     [2]string        `env:"NAMES"`
+		Ports     [2]uint16        `env:"PORTS" min:"1"`
+		Offsets   [2]int8          `env:"OFFSETS"`
+		Durations [2]time.Duration `env:"DURATIONS"`
+		Bytes     [4]byte          `env:"BYTES"`
+		URLs      [1]*url.URL      `env:"URLS"`
+	}
+
+	Convey("arrays", t, func() {
+		u, err := url.Parse("http://example.com")
+		So(err, ShouldBeNil)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Weights:   [3]float64{0.5, 0.25, 0.25},
+			Flags:     [2]bool{true, false},
+			Names:     [2]string{"a", "b"},
+			Ports:     [2]uint16{80, 443},
+			Offsets:   [2]int8{-1, 1},
+			Durations: [2]time.Duration{time.Second, time.Minute},
+			Bytes:     [4]byte{1, 2, 3, 4},
+			URLs:      [1]*url.URL{u},
+		}
+
+		err = Parse(actual, WithSource(Map("test", map[string]string{
+			"WEIGHTS":   "0.5, 0.25, 0.25",
+			"FLAGS":     "true, false",
+			"NAMES":     "a, b",
+			"PORTS":     "80, 443",
+			"OFFSETS":   "-1, 1",
+			"DURATIONS": "1s, 1m",
+			"BYTES":     "1, 2, 3, 4",
+			"URLS":      "http://example.com",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("wrong number of elements", t, func() {
+		tests := map[string]string{
+			"WEIGHTS": "0.5, 0.5",
+			"FLAGS":   "true, false, true",
+			"BYTES":   "1",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "must have exactly")
+		}
+	})
+
+	Convey("invalid elements", t, func() {
+		tests := map[string]string{
+			"PORTS":   "0, 443",
+			"OFFSETS": "1, 128",
+			"BYTES":   "1, 2, 3, x",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("unsupported element type", t, func() {
+		type BadStruct struct {
+			Maps [1]map[string]string `env:"MAPS"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"MAPS": "x"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported")
+	})
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Prefixes that may be given on a number parsed with an explicit base
+	basePrefixes = map[int]string{
+		2:  "0b",
+		8:  "0o",
+		16: "0x",
+	}
+)
+
+// getBase returns the base of an integer field from its base tag. Defaults to
+// 10. A base of 0 selects Go literal syntax, so the base is implied by a 0b, 0o,
+// 0 or 0x prefix.
+func getBase(field reflect.StructField) (int, error) {
+	rawVal, exists := field.Tag.Lookup("base")
+	if !exists || rawVal == "" {
+		return 10, nil
+	}
+	base, err := strconv.Atoi(rawVal)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse tag base on %s: %s", field.Name, err)
+	}
+	if base != 0 && (base < 2 || base > 36) {
+		return 0, fmt.Errorf("unable to parse tag base on %s: base must be 0 or between 2 and 36", field.Name)
+	}
+	return base, nil
+}
+
+// normalizeDigits prepares rawVal for strconv with the given base by removing
+// the base prefix (such as 0x for base 16) and underscores between digits. As in
+// Go literals, an underscore may also follow the prefix, as in 0x_1F. With base
+// 0, strconv handles both itself.
+func normalizeDigits(rawVal string, base int) (string, error) {
+	if base == 0 {
+		return rawVal, nil
+	}
+
+	sign := ""
+	digits := rawVal
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if prefix, exists := basePrefixes[base]; exists && strings.HasPrefix(strings.ToLower(digits), prefix) {
+		digits = digits[len(prefix):]
+		if strings.HasPrefix(digits, "_") {
+			digits = digits[1:]
+		}
+	}
+
+	if !strings.Contains(digits, "_") {
+		return sign + digits, nil
+	}
+	if strings.HasPrefix(digits, "_") || strings.HasSuffix(digits, "_") || strings.Contains(digits, "__") {
+		return "", fmt.Errorf("invalid digit separator in %q", rawVal)
+	}
+	return sign + strings.Replace(digits, "_", "", -1), nil
+}
@@ -0,0 +1,117 @@
+package env
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_bases(t *testing.T) {
+	Convey("explicit bases", t, func() {
+		type TestStruct struct {
+			Mode  uint32 `env:"MODE" base:"8"`
+			Mask  uint64 `env:"MASK" base:"16"`
+			Flags int8   `env:"FLAGS" base:"2"`
+			Count int    `env:"COUNT"`
+		}
+
+		tests := map[string]TestStruct{
+			"MODE=755":          {Mode: 0755},
+			"MODE=0o755":        {Mode: 0755},
+			"MASK=1F":           {Mask: 0x1f},
+			"MASK=0x1f":         {Mask: 0x1f},
+			"MASK=0XFFFF_FFFF":  {Mask: 0xffffffff},
+			"MASK=0x_1F":        {Mask: 0x1f},
+			"MODE=0o_7_5_5":     {Mode: 0755},
+			"FLAGS=-101":        {Flags: -5},
+			"FLAGS=0b0111_1111": {Flags: 127},
+			"COUNT=1_000_000":   {Count: 1000000},
+			"COUNT=-2_500":      {Count: -2500},
+		}
+
+		for keyVal, expected := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			So(err, ShouldBeNil)
+			So(*actual, ShouldResemble, expected)
+		}
+	})
+
+	Convey("auto-detected base", t, func() {
+		type TestStruct struct {
+			IDs []uint16 `env:"IDS" base:"0"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"IDS": "0x1F, 0o17, 017, 0b11, 1_000"})))
+		So(err, ShouldBeNil)
+		So(actual.IDs, ShouldResemble, []uint16{31, 15, 15, 3, 1000})
+	})
+
+	Convey("min/max in the same base", t, func() {
+		type TestStruct struct {
+			Mode uint32 `env:"MODE" base:"8" min:"0600" max:"0o777"`
+		}
+
+		tests := map[string]bool{
+			"577":  false,
+			"600":  true,
+			"755":  true,
+			"777":  true,
+			"1000": false,
+		}
+
+		for value, pass := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"MODE": value})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+	})
+
+	Convey("invalid values", t, func() {
+		type TestStruct struct {
+			Mode  uint32 `env:"MODE" base:"8"`
+			Count int    `env:"COUNT"`
+			Mask  uint64 `env:"MASK" base:"16"`
+		}
+
+		tests := map[string]string{
+			"MODE":  "8",
+			"COUNT": "_1000",
+			"MASK":  "0x__1F",
+		}
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+		}
+
+		for _, value := range []string{"1000_", "1__000", "0x10"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"COUNT": value})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("bad base tag", t, func() {
+		type BadStruct struct {
+			Count int `env:"COUNT" base:"37"`
+		}
+		type NonNumericStruct struct {
+			Count int `env:"COUNT" base:"hex"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"COUNT": "1"}))
+
+		err := Parse(&BadStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag base")
+
+		err = Parse(&NonNumericStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag base")
+	})
+}
@@ -0,0 +1,186 @@
+package env
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	decimalNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)$`)
+)
+
+// handleBigNumber parses *big.Int, *big.Float and *big.Rat fields. Their min and
+// max tags are compared exactly rather than through float64.
+func handleBigNumber(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+
+	var val interface{}
+	var r *big.Rat
+	var err error
+	switch field.Type.Elem() {
+	case bigIntType:
+		var i *big.Int
+		i, err = parseBigInt(field, rawVal)
+		if err == nil {
+			val, r = i, new(big.Rat).SetInt(i)
+		}
+	case bigFloatType:
+		var f *big.Float
+		f, err = parseBigFloat(field, rawVal)
+		if err == nil {
+			val, r = f, ratFromFloat(f)
+		}
+	case bigRatType:
+		r, err = parseRat(rawVal)
+		val = r
+	}
+	if err != nil {
+		return err
+	}
+
+	err = checkRatBounds(field, r, value.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(val))
+	return nil
+}
+
+// parseBigInt parses an integer in the base from the base tag.
+func parseBigInt(field reflect.StructField, rawVal string) (*big.Int, error) {
+	base, err := getBase(field)
+	if err != nil {
+		return nil, err
+	}
+	digits, err := normalizeDigits(rawVal, base)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", rawVal)
+	}
+	return i, nil
+}
+
+// parseBigFloat parses a float with the number of mantissa bits from the
+// precision tag. Defaults to 64 bits, the same as big.Float.
+func parseBigFloat(field reflect.StructField, rawVal string) (*big.Float, error) {
+	prec := uint64(64)
+	if rawPrec, exists := field.Tag.Lookup("precision"); exists {
+		var err error
+		prec, err = strconv.ParseUint(rawPrec, 10, 32)
+		if err != nil || prec == 0 || prec > big.MaxPrec {
+			return nil, fmt.Errorf("unable to parse tag precision on %s: must be between 1 and %d", field.Name, uint64(big.MaxPrec))
+		}
+	}
+
+	f, _, err := big.ParseFloat(rawVal, 10, uint(prec), big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q: %s", rawVal, err)
+	}
+	if f.IsInf() {
+		return nil, fmt.Errorf("%s must be finite", field.Name)
+	}
+	return f, nil
+}
+
+// parseRat parses a fraction (1/3) or decimal (0.25, 1e-3) exactly.
+func parseRat(rawVal string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rawVal)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", rawVal)
+	}
+	return r, nil
+}
+
+func ratFromFloat(f *big.Float) *big.Rat {
+	r, _ := f.Rat(nil)
+	return r
+}
+
+// parseDecimal validates a string field with format:"decimal": a plain decimal
+// number with no exponent, at most as many fractional digits as the scale tag
+// allows, and within the min and max tags compared exactly. The value is kept
+// as a string so no precision is lost.
+func parseDecimal(field reflect.StructField, rawVal string) (string, error) {
+	if !decimalNumber.MatchString(rawVal) {
+		return "", fmt.Errorf("invalid decimal %q", rawVal)
+	}
+
+	if rawScale, exists := field.Tag.Lookup("scale"); exists {
+		scale, err := strconv.Atoi(rawScale)
+		if err != nil || scale < 0 {
+			return "", fmt.Errorf("unable to parse tag scale on %s: must be a non-negative integer", field.Name)
+		}
+		fraction := ""
+		if dot := strings.IndexByte(rawVal, '.'); dot >= 0 {
+			fraction = rawVal[dot+1:]
+		}
+		if len(fraction) > scale {
+			return "", fmt.Errorf("%s must have at most %d decimal places", field.Name, scale)
+		}
+	}
+
+	r, err := parseRat(rawVal)
+	if err != nil {
+		return "", err
+	}
+	err = checkRatBounds(field, r, nil)
+	if err != nil {
+		return "", err
+	}
+	return rawVal, nil
+}
+
+// checkRatBounds compares r against the min and max tags of the field. The tags
+// of *big.Int fields are read in the base from the base tag; all others are
+// decimals or fractions.
+func checkRatBounds(field reflect.StructField, r *big.Rat, t reflect.Type) error {
+	min, err := getRatTag(field, "min", t)
+	if err != nil {
+		return err
+	}
+	max, err := getRatTag(field, "max", t)
+	if err != nil {
+		return err
+	}
+
+	if min != nil && r.Cmp(min) < 0 {
+		return fmt.Errorf("%s must be at least %s", field.Name, field.Tag.Get("min"))
+	}
+	if max != nil && r.Cmp(max) > 0 {
+		return fmt.Errorf("%s must be no more than %s", field.Name, field.Tag.Get("max"))
+	}
+	return nil
+}
+
+func getRatTag(field reflect.StructField, tag string, t reflect.Type) (*big.Rat, error) {
+	rawVal, exists := field.Tag.Lookup(tag)
+	if !exists {
+		return nil, nil
+	}
+
+	var r *big.Rat
+	var err error
+	if t == bigIntType {
+		var i *big.Int
+		i, err = parseBigInt(field, rawVal)
+		if err == nil {
+			r = new(big.Rat).SetInt(i)
+		}
+	} else {
+		r, err = parseRat(rawVal)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tag %s on %s: %s", tag, field.Name, err)
+	}
+	return r, nil
+}
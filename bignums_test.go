@@ -0,0 +1,132 @@
+package env
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_bignums(t *testing.T) {
+	type TestStruct struct {
+		Int   *big.Int   `env:"INT"`
+		Hex   *big.Int   `env:"HEX" base:"16"`
+		Float *big.Float `env:"FLOAT" precision:"128"`
+		Rat   *big.Rat   `env:"RAT"`
+		Price string     `env:"PRICE" format:"decimal" scale:"2"`
+	}
+
+	Convey("values", t, func() {
+		expectedInt, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		expectedFloat, _, _ := big.ParseFloat("0.1", 10, 128, big.ToNearestEven)
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"INT":   "123_456_789_012_345_678_901_234_567_890",
+			"HEX":   "0xFFFFFFFFFFFFFFFFFFFF",
+			"FLOAT": "0.1",
+			"RAT":   "1/3",
+			"PRICE": "19.99",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Int.Cmp(expectedInt), ShouldEqual, 0)
+		So(actual.Hex.Text(16), ShouldEqual, "ffffffffffffffffffff")
+		So(actual.Float.Prec(), ShouldEqual, uint(128))
+		So(actual.Float.Cmp(expectedFloat), ShouldEqual, 0)
+		So(actual.Rat.String(), ShouldEqual, "1/3")
+		So(actual.Price, ShouldEqual, "19.99")
+	})
+
+	Convey("invalid values", t, func() {
+		tests := map[string]string{
+			"INT":   "1.5",
+			"HEX":   "0xZZ",
+			"FLOAT": "one",
+			"RAT":   "1/0",
+			"PRICE": "1e3",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"FLOAT": "Inf"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "must be finite")
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"PRICE": "19.999"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "at most 2 decimal places")
+	})
+
+	Convey("exact min/max", t, func() {
+		type BoundsStruct struct {
+			Int   *big.Int   `env:"INT" min:"9007199254740993" max:"0x20000000000001" base:"0"`
+			Float *big.Float `env:"FLOAT" min:"0.1" precision:"256"`
+			Rat   *big.Rat   `env:"RAT" max:"1/3"`
+			Limit string     `env:"LIMIT" format:"decimal" min:"0.01" max:"10000000000000000.01"`
+		}
+
+		tests := map[string]map[string]bool{
+			// 2^53 + 1 can't be represented as a float64
+			"INT": {
+				"9007199254740992": false,
+				"9007199254740993": true,
+				"9007199254740994": false,
+			},
+			"FLOAT": {
+				"0.1":                   true,
+				"0.0999999999999999999": false,
+			},
+			"RAT": {
+				"1/3":                true,
+				"0.333333333333333":  true,
+				"0.3333333333333334": false,
+			},
+			"LIMIT": {
+				"0.01":                 true,
+				"0.009":                false,
+				"10000000000000000.01": true,
+				"10000000000000000.02": false,
+			},
+		}
+
+		for name, values := range tests {
+			for value, pass := range values {
+				err := Parse(&BoundsStruct{}, WithSource(Map("test", map[string]string{name: value})))
+				if pass {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("bad tags", t, func() {
+		type BadPrecision struct {
+			Float *big.Float `env:"FLOAT" precision:"0"`
+		}
+		type BadScale struct {
+			Price string `env:"PRICE" format:"decimal" scale:"two"`
+		}
+		type BadMin struct {
+			Rat *big.Rat `env:"RAT" min:"one"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"FLOAT": "1", "PRICE": "1", "RAT": "1"}))
+
+		err := Parse(&BadPrecision{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag precision")
+
+		err = Parse(&BadScale{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag scale")
+
+		err = Parse(&BadMin{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag min")
+	})
+}
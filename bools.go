@@ -1,12 +1,53 @@
 package env
 
 import (
+	"fmt"
 	"reflect"
-	"strconv"
+	"strings"
 )
 
-func handleBool(value reflect.Value, rawVal string) error {
-	val, err := parseBool(rawVal)
+var (
+	// The spellings accepted by strconv.ParseBool, which are always allowed
+	defaultTruthy = []string{"1", "t", "true"}
+	defaultFalsy  = []string{"0", "f", "false"}
+)
+
+// boolVocabulary holds the lower-cased words that are accepted as true and false.
+type boolVocabulary struct {
+	truthy map[string]bool
+	falsy  map[string]bool
+}
+
+// getBoolVocabulary combines the default spellings, the words given to
+// WithBoolValues and the truthy and falsy tags of the field.
+func (p *parser) getBoolVocabulary(field reflect.StructField) boolVocabulary {
+	vocab := boolVocabulary{
+		truthy: map[string]bool{},
+		falsy:  map[string]bool{},
+	}
+
+	addWords(vocab.truthy, defaultTruthy)
+	addWords(vocab.truthy, p.truthy)
+	addWords(vocab.truthy, strings.Split(field.Tag.Get("truthy"), ","))
+
+	addWords(vocab.falsy, defaultFalsy)
+	addWords(vocab.falsy, p.falsy)
+	addWords(vocab.falsy, strings.Split(field.Tag.Get("falsy"), ","))
+
+	return vocab
+}
+
+func addWords(set map[string]bool, words []string) {
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			set[word] = true
+		}
+	}
+}
+
+func handleBool(value reflect.Value, vocab boolVocabulary, rawVal string) error {
+	val, err := parseBool(vocab, rawVal)
 	if err != nil {
 		return err
 	}
@@ -14,24 +55,38 @@ func handleBool(value reflect.Value, rawVal string) error {
 	return nil
 }
 
-func parseBool(rawVal string) (bool, error) {
+func parseBool(vocab boolVocabulary, rawVal string) (bool, error) {
 	if rawVal == "" {
 		return false, nil
 	}
-	return strconv.ParseBool(rawVal)
+
+	word := strings.ToLower(rawVal)
+	isTrue := vocab.truthy[word]
+	isFalse := vocab.falsy[word]
+	switch {
+	case isTrue && isFalse:
+		return false, fmt.Errorf("ambiguous boolean value %q is both truthy and falsy", rawVal)
+	case isTrue:
+		return true, nil
+	case isFalse:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q", rawVal)
+	}
 }
 
-func handleBoolSlice(value reflect.Value, rawArr []string) error {
-	if len(rawArr) == 0 {
+func handleBoolSlice(value reflect.Value, vocab boolVocabulary, elems listElements) error {
+	if len(elems.raw) == 0 {
 		return nil
 	}
-	arr := make([]bool, len(rawArr), len(rawArr))
-	for i, rawVal := range rawArr {
-		val, err := parseBool(rawVal)
-		if err != nil {
-			return err
-		}
+	arr := make([]bool, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, rawVal string) error {
+		val, err := parseBool(vocab, rawVal)
 		arr[i] = val
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	value.Set(reflect.ValueOf(arr))
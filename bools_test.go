@@ -36,3 +36,86 @@ func TestParse_bool(t *testing.T) {
 		So(actual, ShouldResemble, expected)
 	})
 }
+
+func TestParse_boolVocabulary(t *testing.T) {
+	Convey("default spellings are case-insensitive", t, func() {
+		type TestStruct struct {
+			Flag bool `env:"FLAG"`
+		}
+
+		tests := map[string]bool{
+			"1":     true,
+			"t":     true,
+			"TRUE":  true,
+			"tRuE":  true,
+			"0":     false,
+			"F":     false,
+			"False": false,
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{Flag: !expected}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"FLAG": value})))
+			So(err, ShouldBeNil)
+			So(actual.Flag, ShouldEqual, expected)
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"FLAG": "yes"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid boolean value")
+	})
+
+	Convey("global vocabulary", t, func() {
+		type TestStruct struct {
+			Flag  bool   `env:"FLAG"`
+			Flags []bool `env:"FLAGS"`
+		}
+
+		opt := WithBoolValues([]string{"yes", "on", "Enabled"}, []string{"no", "off", "disabled"})
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Flag:  true,
+			Flags: []bool{true, false, true, false, true, false},
+		}
+		err := Parse(actual, opt, WithSource(Map("test", map[string]string{
+			"FLAG":  "YES",
+			"FLAGS": "on, OFF, enabled, Disabled, true, no",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("field vocabulary", t, func() {
+		type TestStruct struct {
+			Flag  bool   `env:"FLAG" truthy:"yes, y" falsy:"no, n"`
+			Flags []bool `env:"FLAGS" truthy:"up" falsy:"down"`
+			Other bool   `env:"OTHER"`
+		}
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Flag:  true,
+			Flags: []bool{true, false},
+		}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"FLAG":  "Y",
+			"FLAGS": "UP, down",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"OTHER": "yes"})))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("ambiguous vocabulary", t, func() {
+		type BadStruct struct {
+			Flag bool `env:"FLAG" truthy:"maybe" falsy:"maybe"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"FLAG": "maybe"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "ambiguous")
+	})
+}
@@ -0,0 +1,120 @@
+package env
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// hasEncoding reports whether a []byte or [N]byte field has an encoding tag, in
+// which case it holds binary data rather than a list of numbers.
+func hasEncoding(field reflect.StructField) bool {
+	return field.Tag.Get("encoding") != "" && isByteList(field.Type)
+}
+
+// isByteList reports whether t is a []byte or [N]byte.
+func isByteList(t reflect.Type) bool {
+	kind := t.Kind()
+	return (kind == reflect.Slice || kind == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// handleBytes decodes rawVal according to the encoding tag of the field. Byte
+// fields typically hold keys and salts, so errors never include the value.
+func handleBytes(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+
+	b, err := decodeBytes(field, rawVal)
+	if err != nil {
+		return err
+	}
+
+	length, err := getByteLength(field)
+	if err != nil {
+		return err
+	}
+	if length >= 0 && len(b) != length {
+		return fmt.Errorf("%s must be exactly %d bytes, got %d", field.Name, length, len(b))
+	}
+
+	if value.Kind() == reflect.Array {
+		reflect.Copy(value, reflect.ValueOf(b))
+	} else {
+		value.SetBytes(b)
+	}
+	return nil
+}
+
+func decodeBytes(field reflect.StructField, rawVal string) ([]byte, error) {
+	encoding := field.Tag.Get("encoding")
+	switch encoding {
+	case "raw":
+		return []byte(rawVal), nil
+
+	case "hex":
+		b, err := hex.DecodeString(rawVal)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not valid hex: %s", field.Name, describeHexError(rawVal, err))
+		}
+		return b, nil
+
+	case "base64", "base64url":
+		enc := base64.StdEncoding
+		if encoding == "base64url" {
+			enc = base64.URLEncoding
+		}
+		// Padding is optional
+		if !strings.HasSuffix(rawVal, "=") {
+			enc = enc.WithPadding(base64.NoPadding)
+		}
+
+		b, err := enc.DecodeString(rawVal)
+		if err != nil {
+			if corrupt, ok := err.(base64.CorruptInputError); ok {
+				return nil, fmt.Errorf("%s is not valid %s: illegal data at input byte %d", field.Name, encoding, int64(corrupt))
+			}
+			return nil, fmt.Errorf("%s is not valid %s", field.Name, encoding)
+		}
+		return b, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q on %s", encoding, field.Name)
+	}
+}
+
+// describeHexError describes err without quoting the offending character, which
+// encoding/hex would otherwise include.
+func describeHexError(rawVal string, err error) string {
+	if err == hex.ErrLength {
+		return "odd length"
+	}
+	for i, c := range rawVal {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return fmt.Sprintf("invalid character at input byte %d", i)
+		}
+	}
+	return "invalid data"
+}
+
+// getByteLength returns the required length of a byte field: the length of the
+// array for [N]byte, or the length tag for []byte. It returns -1 if any length
+// is allowed.
+func getByteLength(field reflect.StructField) (int, error) {
+	if field.Type.Kind() == reflect.Array {
+		return field.Type.Len(), nil
+	}
+
+	rawVal, exists := field.Tag.Lookup("length")
+	if !exists {
+		return -1, nil
+	}
+	length, err := strconv.Atoi(rawVal)
+	if err != nil || length < 0 {
+		return 0, fmt.Errorf("unable to parse tag length on %s: must be a non-negative integer", field.Name)
+	}
+	return length, nil
+}
@@ -0,0 +1,101 @@
+package env
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_bytes(t *testing.T) {
+	type TestStruct struct {
+		Base64    []byte  `env:"BASE64" encoding:"base64"`
+		Base64URL []byte  `env:"BASE64URL" encoding:"base64url"`
+		Hex       []byte  `env:"HEX" encoding:"hex"`
+		Raw       []byte  `env:"RAW" encoding:"raw"`
+		Key       [4]byte `env:"KEY" encoding:"hex"`
+		Salt      []byte  `env:"SALT" encoding:"base64" length:"3"`
+		Numbers   []uint8 `env:"NUMBERS"`
+	}
+
+	Convey("encodings", t, func() {
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Base64:    []byte{0xfb, 0xff, 0xbf},
+			Base64URL: []byte{0xfb, 0xff, 0xbf},
+			Hex:       []byte{0xde, 0xad, 0xbe, 0xef},
+			Raw:       []byte("secret"),
+			Key:       [4]byte{0xca, 0xfe, 0xba, 0xbe},
+			Salt:      []byte("abc"),
+			Numbers:   []uint8{1, 2, 3},
+		}
+
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"BASE64":    "+/+/",
+			"BASE64URL": "-_-_",
+			"HEX":       "DEADbeef",
+			"RAW":       "secret",
+			"KEY":       "cafebabe",
+			"SALT":      "YWJj",
+			"NUMBERS":   "1, 2, 3",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("optional base64 padding", t, func() {
+		for _, value := range []string{"YQ==", "YQ"} {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"BASE64": value})))
+			So(err, ShouldBeNil)
+			So(actual.Base64, ShouldResemble, []byte("a"))
+		}
+	})
+
+	Convey("invalid values are not echoed", t, func() {
+		tests := map[string]string{
+			"BASE64":    "s3cr3t!!",
+			"BASE64URL": "s3cr3t+/",
+			"HEX":       "s3cr3t",
+			"KEY":       "abc",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldNotContainSubstring, "s3cr3t")
+			So(err.Error(), ShouldNotContainSubstring, "'s'")
+		}
+	})
+
+	Convey("exact length", t, func() {
+		tests := map[string]string{
+			"KEY":  "cafeba",
+			"SALT": "YWJjZA==",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "must be exactly")
+		}
+	})
+
+	Convey("bad tags", t, func() {
+		type BadEncoding struct {
+			Key []byte `env:"KEY" encoding:"base32"`
+		}
+		type BadLength struct {
+			Key []byte `env:"KEY" encoding:"raw" length:"-1"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"KEY": "abcd"}))
+
+		err := Parse(&BadEncoding{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported encoding")
+
+		err = Parse(&BadLength{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag length")
+	})
+}
@@ -0,0 +1,534 @@
+package env
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// knownTags are the struct tags that Parse reads
+	knownTags = map[string]bool{
+		"env":           true,
+		"required":      true,
+		"default":       true,
+		"min":           true,
+		"max":           true,
+		"exclusiveMin":  true,
+		"exclusiveMax":  true,
+		"multipleOf":    true,
+		"allowNaN":      true,
+		"oneof":         true,
+		"format":        true,
+		"delimiter":     true,
+		"listFormat":    true,
+		"trim":          true,
+		"allowEmpty":    true,
+		"notEmpty":      true,
+		"layout":        true,
+		"unit":          true,
+		"base":          true,
+		"encoding":      true,
+		"length":        true,
+		"truthy":        true,
+		"falsy":         true,
+		"absolute":      true,
+		"schemes":       true,
+		"userinfo":      true,
+		"requirePort":   true,
+		"expandHome":    true,
+		"mustExist":     true,
+		"isDir":         true,
+		"isFile":        true,
+		"family":        true,
+		"precision":     true,
+		"scale":         true,
+		"discriminator": true,
+		"shared":        true,
+		"failFast":      true,
+		"secret":        true,
+	}
+
+	// Tags of other common packages, which are never reported as typos of ours
+	foreignTags = map[string]bool{
+		"json":         true,
+		"yaml":         true,
+		"toml":         true,
+		"xml":          true,
+		"hcl":          true,
+		"bson":         true,
+		"db":           true,
+		"form":         true,
+		"query":        true,
+		"header":       true,
+		"uri":          true,
+		"flag":         true,
+		"mapstructure": true,
+		"validate":     true,
+		"binding":      true,
+		"protobuf":     true,
+		"msgpack":      true,
+		"gorm":         true,
+		"name":         true,
+		"arg":          true,
+		"help":         true,
+		"usage":        true,
+		"description":  true,
+	}
+
+	boolTags = []string{
+		"required", "trim", "allowEmpty", "notEmpty", "allowNaN", "absolute",
+		"requirePort", "expandHome", "mustExist", "isDir", "isFile", "failFast",
+		"secret",
+	}
+
+	enumTags = map[string][]string{
+		"format":     {"json", "hostport", "path", "decimal"},
+		"listFormat": {"csv"},
+		"encoding":   {"raw", "hex", "base64", "base64url"},
+		"family":     {"ipv4", "ipv6"},
+		"userinfo":   {"required", "forbidden"},
+	}
+
+	// Bounds tags; only min and max apply to durations, times and other ordered types
+	boundTags = []string{"min", "max", "exclusiveMin", "exclusiveMax", "multipleOf"}
+
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Check validates the struct tags of conf, which must be a pointer to a struct,
+// without reading any variables. It reports unknown tags that look like typos of
+// known ones, tags that don't parse, bounds, encoding and length tags on fields
+// they don't apply to, min greater than max, defaults that Parse would reject
+// and variable names used by more than one field. The options are the same as those given to Parse.
+func Check(conf interface{}, opts ...Option) error {
+	t := reflect.TypeOf(conf)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+
+	c := &checker{
+		p:        newParser(opts),
+		vars:     bindings{},
+		visiting: map[reflect.Type]bool{},
+	}
+	c.checkStruct(t.Elem(), "", "")
+
+	if len(c.errs) != 0 {
+		return joinErrs(c.errs)
+	}
+	return nil
+}
+
+// MustCheck panics if Check finds a problem with the struct tags of T. It's
+// meant to be called from a unit test or an init function:
+//
+//	func TestConfig(t *testing.T) {
+//		env.MustCheck[Config]()
+//	}
+func MustCheck[T any](opts ...Option) {
+	err := Check(new(T), opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type checker struct {
+	p *parser
+	// The field that reads each variable
+	vars bindings
+	// Struct types being checked, so recursive types don't recurse forever
+	visiting map[reflect.Type]bool
+	errs     []error
+}
+
+func (c *checker) errorf(format string, args ...interface{}) {
+	c.errs = append(c.errs, fmt.Errorf(format, args...))
+}
+
+func (c *checker) checkStruct(t reflect.Type, prefix, path string) {
+	if c.visiting[t] {
+		return
+	}
+	c.visiting[t] = true
+	defer delete(c.visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		c.checkField(field, prefix, joinPath(path, field.Name))
+	}
+}
+
+func (c *checker) checkField(field reflect.StructField, prefix, path string) {
+	c.checkTagNames(field, path)
+
+	envName := strings.TrimSpace(field.Tag.Get("env"))
+	if envName == "-" {
+		return
+	}
+	if isEmbeddedStruct(field) {
+		if envName != "" {
+			prefix += envName + "_"
+		}
+		if field.Type.Kind() == reflect.Ptr && !field.IsExported() {
+			c.errorf("unable to set fields of %s: embedded pointers must be to exported types", path)
+			return
+		}
+		if t := structType(field.Type); c.visiting[t] {
+			c.errorf("unable to parse %s: %s embeds itself", path, t)
+			return
+		}
+		c.checkStruct(structType(field.Type), prefix, path)
+		return
+	}
+	if envName == "" {
+		return
+	}
+	envName = prefix + envName
+	if !field.IsExported() {
+		c.errorf("unable to set unexported field %s from variable [%s]: export the field or remove its env tag", path, envName)
+		return
+	}
+
+	c.checkTagValues(field, path)
+
+	format := field.Tag.Get("format")
+	_, hasDiscriminator := field.Tag.Lookup("discriminator")
+	switch {
+	case isStructSlice(field.Type) && format != "json":
+		c.checkUnusedTags(field, path, []string{"default", "min", "max", "exclusiveMin", "exclusiveMax", "multipleOf"})
+		c.checkStruct(structType(field.Type.Elem()), envName+"_*_", path+"[*]")
+
+	case field.Type.Kind() == reflect.Interface && format != "json":
+		c.checkUnusedTags(field, path, boundTags)
+		c.checkVariants(field, envName, path)
+
+	default:
+		if hasDiscriminator {
+			c.errorf("tag discriminator on %s only applies to interface fields", path)
+		}
+		if !isByteList(field.Type) {
+			c.checkUnusedTags(field, path, []string{"encoding", "length"})
+		}
+		c.addVar(envName, path, field)
+		c.checkBounds(field, path)
+		c.checkDefault(field, envName, path)
+	}
+}
+
+// checkTagNames reports tags that aren't ours but are close enough to one of
+// ours to be a typo, such as Required or defualt.
+func (c *checker) checkTagNames(field reflect.StructField, path string) {
+	for _, name := range tagNames(field.Tag) {
+		if knownTags[name] || foreignTags[name] {
+			continue
+		}
+		if suggestion := closestTag(name); suggestion != "" {
+			c.errorf("unknown tag %s on %s, did you mean %s?", name, path, suggestion)
+		}
+	}
+}
+
+func (c *checker) checkTagValues(field reflect.StructField, path string) {
+	for _, tag := range boolTags {
+		rawVal, exists := field.Tag.Lookup(tag)
+		if !exists {
+			continue
+		}
+		if _, err := strconv.ParseBool(strings.TrimSpace(rawVal)); err != nil {
+			c.errorf("unable to parse tag %s on %s: must be true or false, got %q", tag, path, rawVal)
+		}
+	}
+
+	for tag, allowed := range enumTags {
+		rawVal, exists := field.Tag.Lookup(tag)
+		if !exists || rawVal == "" {
+			continue
+		}
+		if !containsString(allowed, rawVal) {
+			c.errorf("unable to parse tag %s on %s: must be one of [%s], got %q", tag, path, strings.Join(allowed, ", "), rawVal)
+		}
+	}
+}
+
+// checkUnusedTags reports tags that have no effect on the field.
+func (c *checker) checkUnusedTags(field reflect.StructField, path string, tags []string) {
+	for _, tag := range tags {
+		if _, exists := field.Tag.Lookup(tag); exists {
+			c.errorf("tag %s on %s has no effect on %s fields", tag, path, field.Type)
+		}
+	}
+}
+
+// checkVariants checks the discriminator of an interface field and every
+// implementation registered for it.
+func (c *checker) checkVariants(field reflect.StructField, envName, path string) {
+	discriminator := strings.TrimSpace(field.Tag.Get("discriminator"))
+	if discriminator == "" {
+		c.errorf("interface field %s requires a discriminator tag", path)
+		return
+	}
+	c.addVar(envName+"_"+discriminator, path, field)
+
+	names := variantNames(field.Type)
+	if defaultVal := field.Tag.Get("default"); defaultVal != "" && !containsString(names, defaultVal) {
+		c.errorf("invalid default value on %s: must be one of [%s]", path, strings.Join(names, ", "))
+	}
+	for _, name := range names {
+		t, _ := lookupVariant(field.Type, name)
+		c.checkStruct(structType(t), envName+"_"+strings.ToUpper(name)+"_", path)
+	}
+}
+
+// checkBounds checks that the bounds tags apply to the field, parse and are
+// consistent with each other. Bounds of slices and arrays apply to each element.
+func (c *checker) checkBounds(field reflect.StructField, path string) {
+	elemField := field
+	if (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array) && field.Type != ipType && !hasEncoding(field) {
+		elemField.Type = field.Type.Elem()
+	}
+	kind := boundKind(elemField)
+	if field.Tag.Get("format") == "json" {
+		// JSON values are decoded as a whole and never checked against bounds
+		kind = ""
+	}
+
+	bounds := map[string]*big.Rat{}
+	for _, tag := range boundTags {
+		if _, exists := field.Tag.Lookup(tag); !exists {
+			continue
+		}
+		if kind == "" || (kind == "ordered" && tag != "min" && tag != "max") {
+			c.errorf("tag %s on %s has no effect on %s fields", tag, path, field.Type)
+			continue
+		}
+		r, err := getBoundTag(elemField, tag)
+		if err != nil {
+			c.errs = append(c.errs, err)
+			continue
+		}
+		bounds[tag] = r
+	}
+
+	if bounds["min"] != nil && bounds["max"] != nil && bounds["min"].Cmp(bounds["max"]) > 0 {
+		c.errorf("min %s is greater than max %s on %s", field.Tag.Get("min"), field.Tag.Get("max"), path)
+	}
+	if bounds["exclusiveMin"] != nil && bounds["exclusiveMax"] != nil && bounds["exclusiveMin"].Cmp(bounds["exclusiveMax"]) >= 0 {
+		c.errorf("exclusiveMin %s must be less than exclusiveMax %s on %s", field.Tag.Get("exclusiveMin"), field.Tag.Get("exclusiveMax"), path)
+	}
+	if bounds["multipleOf"] != nil && bounds["multipleOf"].Sign() == 0 {
+		c.errorf("unable to parse tag multipleOf on %s: must not be zero", path)
+	}
+}
+
+// boundKind returns "number" for fields that support every bounds tag, "ordered"
+// for fields that only support min and max, and "" for everything else.
+func boundKind(field reflect.StructField) string {
+	t := field.Type
+	switch {
+	case t == durationType, t == levelType, t == timeType:
+		return "ordered"
+	case t.Kind() == reflect.Ptr && (t.Elem() == bigIntType || t.Elem() == bigFloatType || t.Elem() == bigRatType):
+		return "ordered"
+	case t.Kind() == reflect.String && field.Tag.Get("format") == "decimal":
+		return "ordered"
+	}
+
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// getBoundTag parses a bounds tag the same way Parse does, converted to a
+// rational so bounds of any type can be compared. Infinite float bounds are
+// returned as nil since they can't be compared.
+func getBoundTag(field reflect.StructField, tag string) (*big.Rat, error) {
+	t := field.Type
+	switch {
+	case t == durationType:
+		d, err := getDurationTag(field, tag, 0)
+		return new(big.Rat).SetInt64(int64(d)), err
+	case t == levelType:
+		level, err := getLevelTag(field, tag)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetInt64(int64(*level)), nil
+	case t == timeType:
+		tm, _, err := getTimeTag(field, tag, getTimeLayout(field))
+		nanos := new(big.Int).Mul(big.NewInt(tm.Unix()), big.NewInt(int64(time.Second)))
+		nanos.Add(nanos, big.NewInt(int64(tm.Nanosecond())))
+		return new(big.Rat).SetInt(nanos), err
+	case t.Kind() == reflect.Ptr:
+		return getRatTag(field, tag, t.Elem())
+	case t.Kind() == reflect.String:
+		return getRatTag(field, tag, nil)
+	}
+
+	size, err := getSize(field)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := getIntTag(field, tag, 0, size)
+		return new(big.Rat).SetInt64(i), err
+	case reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		i, err := getUintTag(field, tag, 0, size)
+		return new(big.Rat).SetUint64(i), err
+	default:
+		f, err := getFloatTag(field, tag, 0, size)
+		if err != nil {
+			return nil, err
+		}
+		// SetFloat64 returns nil for infinities and NaN
+		return new(big.Rat).SetFloat64(f), nil
+	}
+}
+
+// checkDefault parses the default tag the way Parse would, so a default that
+// doesn't parse, is out of bounds or isn't one of the oneof choices is reported.
+// Tags that depend on the file system are ignored.
+func (c *checker) checkDefault(field reflect.StructField, envName, path string) {
+	defaultVal := field.Tag.Get("default")
+	if defaultVal == "" {
+		return
+	}
+
+	defaultField := field
+	defaultField.Tag = withoutTags(field.Tag, "mustExist", "isDir", "isFile")
+	value := reflect.New(field.Type).Elem()
+
+	var err error
+	if field.Tag.Get("format") == "json" {
+		err = handleJSON(value, describeVar(envName, "default"), defaultVal)
+	} else {
+		err = c.p.parseField(value, defaultField, envName, defaultVal)
+	}
+	if err != nil {
+		c.errorf("invalid default value on %s: %s", path, err)
+	}
+}
+
+// addVar records that the field at path reads the variable envName.
+func (c *checker) addVar(envName, path string, field reflect.StructField) {
+	err := c.vars.bind(envName, path, field)
+	if err != nil {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// tagNames returns the names of the tags in tag, which is assumed to be in the
+// conventional format understood by reflect.StructTag.Lookup.
+func tagNames(tag reflect.StructTag) []string {
+	names := []string{}
+	for tag != "" {
+		tag = reflect.StructTag(strings.TrimLeft(string(tag), " "))
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		// Skip the quoted value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		tag = tag[i+1:]
+		names = append(names, name)
+	}
+	return names
+}
+
+// withoutTags returns tag with the named tags removed.
+func withoutTags(tag reflect.StructTag, remove ...string) reflect.StructTag {
+	kept := []string{}
+	for _, name := range tagNames(tag) {
+		if containsString(remove, name) {
+			continue
+		}
+		kept = append(kept, name+":"+strconv.Quote(tag.Get(name)))
+	}
+	return reflect.StructTag(strings.Join(kept, " "))
+}
+
+// closestTag returns the known tag that name is most likely a typo of, or "" if
+// there isn't one. Case is ignored and short tags allow fewer edits.
+func closestTag(name string) string {
+	best, bestDist := "", -1
+	for known := range knownTags {
+		maxDist := 2
+		if len(known) <= 4 {
+			maxDist = 1
+		}
+		dist := editDistance(strings.ToLower(name), strings.ToLower(known))
+		if dist <= maxDist && (bestDist < 0 || dist < bestDist || (dist == bestDist && known < best)) {
+			best, bestDist = known, dist
+		}
+	}
+	return best
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	dist := make([][]int, len(a)+1)
+	for i := range dist {
+		dist[i] = make([]int, len(b)+1)
+		dist[i][0] = i
+	}
+	for j := range dist[0] {
+		dist[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			dist[i][j] = minInt(dist[i-1][j]+1, dist[i][j-1]+1, dist[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				dist[i][j] = minInt(dist[i][j], dist[i-2][j-2]+1)
+			}
+		}
+	}
+	return dist[len(a)][len(b)]
+}
+
+func minInt(vals ...int) int {
+	min := vals[0]
+	for _, val := range vals[1:] {
+		if val < min {
+			min = val
+		}
+	}
+	return min
+}
+
+func containsString(arr []string, s string) bool {
+	for _, val := range arr {
+		if val == s {
+			return true
+		}
+	}
+	return false
+}
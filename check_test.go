@@ -0,0 +1,216 @@
+package env
+
+import (
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheck(t *testing.T) {
+	Convey("valid structs", t, func() {
+		type Upstream struct {
+			Host string `env:"HOST" required:"true" format:"hostport"`
+			Port int    `env:"PORT" default:"80" min:"1" max:"65535"`
+		}
+		type TestStruct struct {
+			TestDatabase `env:"DB"`
+			Name         string        `env:"NAME" json:"name" yaml:"name" oneof:"api,worker" default:"api"`
+			Timeout      time.Duration `env:"TIMEOUT" default:"30s" min:"1s" max:"1m"`
+			Rate         float64       `env:"RATE" unit:"percent" default:"10%" max:"50%"`
+			Workers      []int         `env:"WORKERS" min:"1" exclusiveMax:"64" multipleOf:"2"`
+			Since        time.Time     `env:"SINCE" layout:"DateOnly" min:"2000-01-01" max:"2100-01-01" default:"2020-06-01"`
+			Budget       *big.Rat      `env:"BUDGET" min:"0" max:"1/2"`
+			Price        string        `env:"PRICE" format:"decimal" scale:"2" min:"0.01" default:"9.99"`
+			Endpoint     *url.URL      `env:"ENDPOINT" absolute:"true" default:"https://example.com"`
+			Upstreams    []Upstream    `env:"UPSTREAM" required:"true"`
+			Cache        testCache     `env:"CACHE" discriminator:"BACKEND" default:"memory"`
+			Ignored      string
+			Skipped      string `env:"-" min:"1"`
+		}
+
+		So(Check(&TestStruct{}), ShouldBeNil)
+		So(func() { MustCheck[TestStruct]() }, ShouldNotPanic)
+		So(func() { MustCheck[LogConfig]() }, ShouldNotPanic)
+	})
+
+	Convey("not a struct pointer", t, func() {
+		So(Check(TestConfig{}), ShouldEqual, ErrNotStructPointer)
+		So(Check(nil), ShouldEqual, ErrNotStructPointer)
+		So(func() { MustCheck[int]() }, ShouldPanic)
+	})
+
+	Convey("unknown tags", t, func() {
+		type TestStruct struct {
+			Host string `env:"HOST" requried:"true"`
+			Port int    `env:"PORT" Default:"80"`
+			Name string `evn:"NAME"`
+			User string `env:"USER" json:"user" mapstructure:"user"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unknown tag requried on Host, did you mean required?")
+		So(err.Error(), ShouldContainSubstring, "unknown tag Default on Port, did you mean default?")
+		So(err.Error(), ShouldContainSubstring, "unknown tag evn on Name, did you mean env?")
+		So(err.Error(), ShouldNotContainSubstring, "User")
+	})
+
+	Convey("tags that don't parse", t, func() {
+		type TestStruct struct {
+			Host    string   `env:"HOST" required:"ture"`
+			Port    int      `env:"PORT" min:"one"`
+			Addrs   []string `env:"ADDRS" format:"hostpost"`
+			Size    int64    `env:"SIZE" unit:"bytes" max:"1XB"`
+			Timeout uint     `env:"TIMEOUT" multipleOf:"0"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `unable to parse tag required on Host: must be true or false, got "ture"`)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag min on Port")
+		So(err.Error(), ShouldContainSubstring, `unable to parse tag format on Addrs: must be one of [json, hostport, path, decimal], got "hostpost"`)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag max on Size")
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag multipleOf on Timeout: must not be zero")
+	})
+
+	Convey("bounds on fields they don't apply to", t, func() {
+		type TestStruct struct {
+			Name    string        `env:"NAME" min:"3"`
+			Enabled bool          `env:"ENABLED" max:"1"`
+			Timeout time.Duration `env:"TIMEOUT" multipleOf:"1s"`
+			Key     []byte        `env:"KEY" encoding:"hex" min:"1"`
+			Ports   []int         `env:"PORTS" format:"json" min:"1"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "tag min on Name has no effect on string fields")
+		So(err.Error(), ShouldContainSubstring, "tag max on Enabled has no effect on bool fields")
+		So(err.Error(), ShouldContainSubstring, "tag multipleOf on Timeout has no effect on time.Duration fields")
+		So(err.Error(), ShouldContainSubstring, "tag min on Key has no effect on []uint8 fields")
+		So(err.Error(), ShouldContainSubstring, "tag min on Ports has no effect on []int fields")
+	})
+
+	Convey("encoding and length on fields that aren't bytes", t, func() {
+		type TestStruct struct {
+			Port  int       `env:"PORT" encoding:"hex"`
+			Token string    `env:"TOKEN" encoding:"base64" length:"32"`
+			Keys  [][]byte  `env:"KEYS" length:"16"`
+			Key   []byte    `env:"KEY" encoding:"hex" length:"32"`
+			Nonce [12]uint8 `env:"NONCE" encoding:"base64"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "tag encoding on Port has no effect on int fields")
+		So(err.Error(), ShouldContainSubstring, "tag encoding on Token has no effect on string fields")
+		So(err.Error(), ShouldContainSubstring, "tag length on Token has no effect on string fields")
+		So(err.Error(), ShouldContainSubstring, "tag length on Keys has no effect on [][]uint8 fields")
+		So(err.Error(), ShouldNotContainSubstring, "on Key ")
+		So(err.Error(), ShouldNotContainSubstring, "Nonce")
+	})
+
+	Convey("min greater than max", t, func() {
+		type TestStruct struct {
+			Port    int           `env:"PORT" min:"100" max:"10"`
+			Size    uint64        `env:"SIZE" unit:"bytes" min:"1GiB" max:"1GB"`
+			Timeout time.Duration `env:"TIMEOUT" min:"1w" max:"6d"`
+			Rate    float64       `env:"RATE" exclusiveMin:"0.5" exclusiveMax:"0.5"`
+			Mode    uint32        `env:"MODE" base:"8" min:"0777" max:"0755"`
+			Valid   int           `env:"VALID" min:"-10" max:"10"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "min 100 is greater than max 10 on Port")
+		So(err.Error(), ShouldContainSubstring, "min 1GiB is greater than max 1GB on Size")
+		So(err.Error(), ShouldContainSubstring, "min 1w is greater than max 6d on Timeout")
+		So(err.Error(), ShouldContainSubstring, "exclusiveMin 0.5 must be less than exclusiveMax 0.5 on Rate")
+		So(err.Error(), ShouldContainSubstring, "min 0777 is greater than max 0755 on Mode")
+		So(err.Error(), ShouldNotContainSubstring, "Valid")
+	})
+
+	Convey("invalid defaults", t, func() {
+		type TestStruct struct {
+			Port   int       `env:"PORT" default:"http"`
+			Level  string    `env:"LEVEL" oneof:"debug,info" default:"warn"`
+			Rate   float64   `env:"RATE" max:"1" default:"1.5"`
+			Hosts  []string  `env:"HOSTS" format:"hostport" default:"a:1,b"`
+			Config []int     `env:"CONFIG" format:"json" default:"[1,"`
+			Cache  testCache `env:"CACHE" discriminator:"BACKEND" default:"memcached"`
+			Path   string    `env:"PATH_TO_CONFIG" format:"path" mustExist:"true" default:"/does/not/exist"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Port")
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Level: Level must be one of [debug, info]")
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Rate: Rate must be no more than")
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Hosts")
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Config")
+		So(err.Error(), ShouldContainSubstring, "invalid default value on Cache: must be one of [memory, redis]")
+		So(err.Error(), ShouldNotContainSubstring, "Path")
+	})
+
+	Convey("duplicate variable names", t, func() {
+		type Upstream struct {
+			Host string `env:"HOST"`
+			Addr string `env:"HOST"`
+		}
+		type TestStruct struct {
+			TestDatabase `env:"DB"`
+			DBHost       string     `env:"DB_HOST"`
+			Upstreams    []Upstream `env:"UPSTREAM"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [DB_HOST] is used by both TestDatabase.Host and DBHost")
+		So(err.Error(), ShouldContainSubstring, "variable [UPSTREAM_*_HOST] is used by both Upstreams[*].Host and Upstreams[*].Addr")
+	})
+
+	Convey("fields Parse can't set", t, func() {
+		type TestStruct struct {
+			host  string    `env:"HOST"`
+			Port  int       `env:"PORT" discriminator:"KIND"`
+			Cache testCache `env:"CACHE"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to set unexported field host")
+		So(err.Error(), ShouldContainSubstring, "tag discriminator on Port only applies to interface fields")
+		So(err.Error(), ShouldContainSubstring, "interface field Cache requires a discriminator tag")
+	})
+
+	Convey("recursive types", t, func() {
+		So(Check(&testNode{}), ShouldBeNil)
+	})
+}
+
+type testNode struct {
+	Name     string     `env:"NAME"`
+	Children []testNode `env:"CHILD"`
+}
+
+func TestEditDistance(t *testing.T) {
+	Convey("edit distance", t, func() {
+		tests := map[string]int{
+			"":                  0,
+			"min,":              3,
+			"env,evn":           1,
+			"required,requried": 1,
+			"kitten,sitting":    3,
+			"default,Default":   1,
+			"max,min":           2,
+		}
+		for pair, expected := range tests {
+			split := strings.SplitN(pair+",", ",", 3)
+			So(editDistance(split[0], split[1]), ShouldEqual, expected)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotenvFile reads a dotenv file at path and returns it as a Source.
+func DotenvFile(path string) (Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Dotenv(path, f)
+}
+
+// Dotenv reads KEY=VALUE lines from r and returns them as a Source named name.
+// Blank lines and lines starting with # are ignored, and an optional leading
+// "export " is stripped. Values may be wrapped in single quotes (taken
+// literally) or double quotes (which support \n, \t, \" and \\ escapes).
+func Dotenv(name string, r io.Reader) (Source, error) {
+	values := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		keyVal := strings.SplitN(line, "=", 2)
+		if len(keyVal) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", name, lineNum)
+		}
+
+		key := strings.TrimSpace(keyVal[0])
+		if key == "" {
+			return nil, fmt.Errorf("%s:%d: missing variable name", name, lineNum)
+		}
+
+		val, err := unquoteDotenv(strings.TrimSpace(keyVal[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, lineNum, err)
+		}
+		values[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return Map(name, values), nil
+}
+
+func unquoteDotenv(rawVal string) (string, error) {
+	if rawVal == "" {
+		return "", nil
+	}
+
+	quote := rawVal[0]
+	if quote != '"' && quote != '\'' {
+		// Unquoted values may have a trailing comment
+		if i := strings.Index(rawVal, " #"); i >= 0 {
+			rawVal = strings.TrimSpace(rawVal[:i])
+		}
+		return rawVal, nil
+	}
+
+	end := closingQuote(rawVal, quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated quoted value")
+	}
+	rest := strings.TrimSpace(rawVal[end+1:])
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected characters after quoted value")
+	}
+
+	val := rawVal[1:end]
+	if quote == '\'' {
+		return val, nil
+	}
+
+	replacer := strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+	return replacer.Replace(val), nil
+}
+
+// closingQuote returns the index of the quote that terminates the value opened
+// by rawVal[0], or -1 if there is none. Double quotes may be escaped.
+func closingQuote(rawVal string, quote byte) int {
+	for i := 1; i < len(rawVal); i++ {
+		switch {
+		case rawVal[i] == '\\' && quote == '"':
+			i++
+		case rawVal[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,87 @@
+package env
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDotenv(t *testing.T) {
+	Convey("parses values", t, func() {
+		contents := strings.Join([]string{
+			"# a comment",
+			"",
+			"PLAIN=value",
+			"export EXPORTED=yes",
+			"SPACED = padded  ",
+			"COMMENTED=abc # trailing",
+			`DOUBLE="line1\nline2 \"quoted\""`,
+			`EMPTY=`,
+		}, "\n")
+
+		src, err := Dotenv("test.env", strings.NewReader(contents))
+		So(err, ShouldBeNil)
+		So(src.String(), ShouldEqual, "test.env")
+
+		tests := map[string]string{
+			"PLAIN":     "value",
+			"EXPORTED":  "yes",
+			"SPACED":    "padded",
+			"COMMENTED": "abc",
+			"DOUBLE":    "line1\nline2 \"quoted\"",
+			"EMPTY":     "",
+		}
+		for key, expected := range tests {
+			val, exists := src.Lookup(key)
+			So(exists, ShouldBeTrue)
+			So(val, ShouldEqual, expected)
+		}
+
+		_, exists := src.Lookup("MISSING")
+		So(exists, ShouldBeFalse)
+	})
+
+	Convey("single quoted values are literal", t, func() {
+		src, err := Dotenv("test.env", strings.NewReader(`KEY='a \n # b'`))
+		So(err, ShouldBeNil)
+		val, _ := src.Lookup("KEY")
+		So(val, ShouldEqual, `a \n # b`)
+	})
+
+	Convey("bad lines", t, func() {
+		tests := []string{
+			"NOEQUALS",
+			"=value",
+			`KEY="unterminated`,
+			`KEY="quoted" junk`,
+		}
+		for _, line := range tests {
+			_, err := Dotenv("bad.env", strings.NewReader(line))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "bad.env:1")
+		}
+	})
+
+	Convey("file", t, func() {
+		dir, err := ioutil.TempDir("", "env")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, ".env")
+		err = ioutil.WriteFile(path, []byte("KEY=value\n"), 0600)
+		So(err, ShouldBeNil)
+
+		src, err := DotenvFile(path)
+		So(err, ShouldBeNil)
+		val, exists := src.Lookup("KEY")
+		So(exists, ShouldBeTrue)
+		So(val, ShouldEqual, "value")
+
+		_, err = DotenvFile(filepath.Join(dir, "missing.env"))
+		So(err, ShouldNotBeNil)
+	})
+}
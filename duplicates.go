@@ -0,0 +1,44 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// varBinding records the field that reads a variable.
+type varBinding struct {
+	path       string
+	shared     bool
+	defaultVal string
+}
+
+// bindings maps the name of each variable to the field that reads it.
+type bindings map[string]varBinding
+
+// bind records that the field at path reads envName. Reading a variable from
+// more than one field is an error unless all of them are tagged shared:"true",
+// and shared fields must have the same default.
+func (b bindings) bind(envName, path string, field reflect.StructField) error {
+	shared, err := getBoolTag(field, "shared", false)
+	if err != nil {
+		return fmt.Errorf("unable to parse tag shared on %s: %s", field.Name, err)
+	}
+	binding := varBinding{
+		path:       path,
+		shared:     shared,
+		defaultVal: field.Tag.Get("default"),
+	}
+
+	other, exists := b[envName]
+	if !exists {
+		b[envName] = binding
+		return nil
+	}
+	if !other.shared || !binding.shared {
+		return fmt.Errorf(`variable [%s] is used by both %s and %s; tag both fields with shared:"true" if this is intended`, envName, other.path, path)
+	}
+	if other.defaultVal != binding.defaultVal {
+		return fmt.Errorf("shared variable [%s] has different defaults on %s and %s", envName, other.path, path)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package env
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_duplicates(t *testing.T) {
+	src := WithSource(Map("test", map[string]string{
+		"DB_HOST":          "db.example.com",
+		"UPSTREAM_0_HOST":  "a",
+		"CACHE_BACKEND":    "redis",
+		"CACHE_REDIS_ADDR": "localhost:6379",
+	}))
+
+	Convey("duplicates across embedded structs", t, func() {
+		type TestStruct struct {
+			TestDatabase `env:"DB"`
+			Host         string `env:"DB_HOST"`
+		}
+
+		err := Parse(&TestStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `variable [DB_HOST] is used by both TestDatabase.Host and Host; tag both fields with shared:"true" if this is intended`)
+	})
+
+	Convey("duplicates across struct slices and interfaces", t, func() {
+		type Upstream struct {
+			Host string `env:"HOST"`
+		}
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+			First     string     `env:"UPSTREAM_0_HOST"`
+			Cache     testCache  `env:"CACHE" discriminator:"BACKEND"`
+			RedisAddr string     `env:"CACHE_REDIS_ADDR"`
+		}
+
+		err := Parse(&TestStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [UPSTREAM_0_HOST] is used by both Upstreams[0].Host and First")
+		So(err.Error(), ShouldContainSubstring, "variable [CACHE_REDIS_ADDR] is used by both Cache.Addr and RedisAddr")
+	})
+
+	Convey("shared variables", t, func() {
+		type TestStruct struct {
+			TestDatabase `env:"DB"`
+			Host         string `env:"DB_HOST" default:"localhost" shared:"true"`
+		}
+
+		err := Parse(&TestStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [DB_HOST] is used by both TestDatabase.Host and Host")
+
+		type Database struct {
+			Host string `env:"HOST" default:"localhost" shared:"true"`
+		}
+		type SharedStruct struct {
+			Database `env:"DB"`
+			Host     string `env:"DB_HOST" default:"localhost" shared:"true"`
+		}
+
+		actual := &SharedStruct{}
+		err = Parse(actual, src)
+		So(err, ShouldBeNil)
+		So(actual.Database.Host, ShouldEqual, "db.example.com")
+		So(actual.Host, ShouldEqual, "db.example.com")
+
+		type DefaultsStruct struct {
+			Database `env:"DB"`
+			Host     string `env:"DB_HOST" default:"127.0.0.1" shared:"true"`
+		}
+
+		err = Parse(&DefaultsStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "shared variable [DB_HOST] has different defaults on Database.Host and Host")
+	})
+
+	Convey("duplicates in Check", t, func() {
+		type Database struct {
+			Host string `env:"HOST" shared:"true"`
+		}
+		type TestStruct struct {
+			Primary Database `env:"-"`
+			Database
+			Host    string `env:"HOST" shared:"true"`
+			Port    int    `env:"PORT"`
+			Listen  int    `env:"PORT"`
+			Verbose bool   `env:"VERBOSE" shared:"yes"`
+		}
+
+		err := Check(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldNotContainSubstring, "[HOST]")
+		So(err.Error(), ShouldContainSubstring, "variable [PORT] is used by both Port and Listen")
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag shared on Verbose")
+	})
+}
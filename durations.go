@@ -0,0 +1,152 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// Units that are not understood by time.ParseDuration, in hours
+	longUnits = map[string]time.Duration{
+		"d": 24,
+		"w": 7 * 24,
+	}
+
+	// Units that may be given in the unit tag of a duration field
+	durationUnits = map[string]bool{
+		"ns": true,
+		"us": true,
+		"µs": true,
+		"ms": true,
+		"s":  true,
+		"m":  true,
+		"h":  true,
+		"d":  true,
+		"w":  true,
+	}
+
+	bareNumber        = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)$`)
+	durationComponent = regexp.MustCompile(`^(\d+\.?\d*|\.\d+)([a-zµμ]+)`)
+	isoDuration       = regexp.MustCompile(`^([-+])?P` +
+		`(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?` +
+		`(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+)
+
+// parseDurationString parses a duration in the extended syntax: anything
+// time.ParseDuration accepts plus the d (24h) and w (7d) units, ISO-8601
+// durations such as P1DT2H, and bare numbers in the unit from the unit tag.
+func parseDurationString(field reflect.StructField, rawVal string) (time.Duration, error) {
+	unit := field.Tag.Get("unit")
+	if unit != "" && !durationUnits[unit] {
+		return 0, fmt.Errorf("unsupported unit %q on %s", unit, field.Name)
+	}
+
+	if unit != "" && bareNumber.MatchString(rawVal) {
+		rawVal += unit
+	}
+
+	if strings.HasPrefix(strings.TrimLeft(rawVal, "+-"), "P") {
+		return parseISODuration(rawVal)
+	}
+	return parseExtendedDuration(rawVal)
+}
+
+// parseExtendedDuration parses Go duration syntax extended with days and weeks,
+// such as 1w2d or 1.5d.
+func parseExtendedDuration(rawVal string) (time.Duration, error) {
+	rest := rawVal
+	negative := false
+	if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+		negative = rest[0] == '-'
+		rest = rest[1:]
+	}
+	if rest == "0" {
+		return 0, nil
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", rawVal)
+	}
+
+	var total time.Duration
+	for rest != "" {
+		match := durationComponent.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q", rawVal)
+		}
+		rest = rest[len(match[0]):]
+
+		dur, err := durationComponentValue(match[1], match[2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s", rawVal, err)
+		}
+		total, err = addDurations(total, dur)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s", rawVal, err)
+		}
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseISODuration parses an ISO-8601 duration such as P1W, P1DT2H30M or PT0.5S.
+// Years and months are rejected because their length varies.
+func parseISODuration(rawVal string) (time.Duration, error) {
+	match := isoDuration.FindStringSubmatch(rawVal)
+	if match == nil || strings.HasSuffix(rawVal, "P") || strings.HasSuffix(rawVal, "T") {
+		return 0, fmt.Errorf("invalid ISO-8601 duration %q", rawVal)
+	}
+	if match[2] != "" || match[3] != "" {
+		return 0, fmt.Errorf("invalid ISO-8601 duration %q: years and months are not supported", rawVal)
+	}
+
+	units := []string{"w", "d", "h", "m", "s"}
+	var total time.Duration
+	for i, num := range match[4:] {
+		if num == "" {
+			continue
+		}
+		dur, err := durationComponentValue(strings.Replace(num, ",", ".", 1), units[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q: %s", rawVal, err)
+		}
+		total, err = addDurations(total, dur)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q: %s", rawVal, err)
+		}
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// durationComponentValue returns the duration of a single number and unit.
+func durationComponentValue(num, unit string) (time.Duration, error) {
+	hours, isLong := longUnits[unit]
+	if !isLong {
+		return time.ParseDuration(num + unit)
+	}
+
+	dur, err := time.ParseDuration(num + "h")
+	if err != nil {
+		return 0, err
+	}
+	if dur > maxDuration/hours {
+		return 0, fmt.Errorf("duration out of range")
+	}
+	return dur * hours, nil
+}
+
+func addDurations(a, b time.Duration) (time.Duration, error) {
+	if a > maxDuration-b {
+		return 0, fmt.Errorf("duration out of range")
+	}
+	return a + b, nil
+}
@@ -94,3 +94,97 @@ func TestParse_duration(t *testing.T) {
 		}
 	})
 }
+
+func TestParse_extendedDuration(t *testing.T) {
+	Convey("days, weeks and ISO-8601", t, func() {
+		type TestStruct struct {
+			Dur time.Duration `env:"DUR"`
+		}
+
+		day := 24 * time.Hour
+		tests := map[string]time.Duration{
+			"7d":         7 * day,
+			"2w":         14 * day,
+			"1w2d3h4m":   9*day + 3*time.Hour + 4*time.Minute,
+			"1.5d":       36 * time.Hour,
+			"-1d12h":     -36 * time.Hour,
+			"0":          0,
+			"1h30m":      90 * time.Minute,
+			"P1D":        day,
+			"P1W":        7 * day,
+			"P1DT2H":     26 * time.Hour,
+			"PT1H30M":    90 * time.Minute,
+			"PT0.5S":     500 * time.Millisecond,
+			"PT1,5M":     90 * time.Second,
+			"-P1DT1M":    -(day + time.Minute),
+			"P2DT3H4M5S": 2*day + 3*time.Hour + 4*time.Minute + 5*time.Second,
+			"500ms":      500 * time.Millisecond,
+			"1h1w":       7*day + time.Hour,
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"DUR": value})))
+			So(err, ShouldBeNil)
+			So(actual.Dur, ShouldEqual, expected)
+		}
+
+		invalid := []string{"30", "1x", "d", "P", "PT", "P1DT", "P1Y", "P1M", "1d-2h", "100000000w", "P100000000W"}
+		for _, value := range invalid {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"DUR": value})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("bare numbers with unit", t, func() {
+		type TestStruct struct {
+			Timeout time.Duration   `env:"TIMEOUT" unit:"s"`
+			Windows []time.Duration `env:"WINDOWS" unit:"d"`
+		}
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Timeout: 30 * time.Second,
+			Windows: []time.Duration{24 * time.Hour, 12 * time.Hour, time.Hour},
+		}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"TIMEOUT": "30",
+			"WINDOWS": "1, 0.5, 1h",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("extended min/max", t, func() {
+		type TestStruct struct {
+			Retention time.Duration `env:"RETENTION" unit:"d" min:"1" max:"P2W"`
+		}
+
+		tests := map[string]bool{
+			"12h": false,
+			"1":   true,
+			"7d":  true,
+			"2w":  true,
+			"15":  false,
+		}
+
+		for value, pass := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RETENTION": value})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+	})
+
+	Convey("unsupported unit", t, func() {
+		type BadStruct struct {
+			Timeout time.Duration `env:"TIMEOUT" unit:"fortnights"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"TIMEOUT": "1"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported unit")
+	})
+}
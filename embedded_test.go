@@ -0,0 +1,144 @@
+package env
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type TestBase struct {
+	Name  string `env:"NAME" required:"true"`
+	Debug bool   `env:"DEBUG"`
+}
+
+type TestDatabase struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type testHidden struct {
+	Token string `env:"TOKEN"`
+}
+
+type TestRecursive struct {
+	*TestRecursive
+	Name string `env:"NAME"`
+}
+
+type TestCycleA struct {
+	*TestCycleB
+	A int `env:"A"`
+}
+
+type TestCycleB struct {
+	*TestCycleA
+	B int `env:"B"`
+}
+
+func TestParse_embedded(t *testing.T) {
+	Convey("embedded structs are flattened", t, func() {
+		type TestStruct struct {
+			TestBase
+			Workers int `env:"WORKERS"`
+		}
+
+		actual := &TestStruct{}
+		prov := Provenance{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"NAME":    "api",
+			"DEBUG":   "true",
+			"WORKERS": "4",
+		})), WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &TestStruct{TestBase: TestBase{Name: "api", Debug: true}, Workers: 4})
+		So(prov["TestBase.Name"], ShouldEqual, "test")
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [NAME]")
+	})
+
+	Convey("the env tag adds a prefix", t, func() {
+		type TestStruct struct {
+			TestDatabase `env:"DB"`
+			*TestBase    `env:"APP"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"DB_HOST":  "db.example.com",
+			"APP_NAME": "api",
+			"HOST":     "ignored",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.TestDatabase, ShouldResemble, TestDatabase{Host: "db.example.com", Port: 5432})
+		So(actual.TestBase, ShouldResemble, &TestBase{Name: "api"})
+	})
+
+	Convey("env:\"-\" skips embedded structs", t, func() {
+		type TestStruct struct {
+			TestBase `env:"-"`
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{})))
+		So(err, ShouldBeNil)
+	})
+
+	Convey("embedded unexported structs", t, func() {
+		type TestStruct struct {
+			testHidden
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"TOKEN": "abc"})))
+		So(err, ShouldBeNil)
+		So(actual.Token, ShouldEqual, "abc")
+
+		type PointerStruct struct {
+			*testHidden
+		}
+		So(func() {
+			err = Parse(&PointerStruct{}, WithSource(Map("test", map[string]string{"TOKEN": "abc"})))
+		}, ShouldNotPanic)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "embedded pointers must be to exported types")
+	})
+}
+
+func TestParse_unexported(t *testing.T) {
+	Convey("unexported fields with env tags are an error", t, func() {
+		type TestStruct struct {
+			Host     string `env:"HOST"`
+			password string `env:"PASSWORD"`
+			internal int
+		}
+
+		var err error
+		actual := &TestStruct{}
+		So(func() {
+			err = Parse(actual, WithSource(Map("test", map[string]string{"HOST": "localhost", "PASSWORD": "hunter2"})))
+		}, ShouldNotPanic)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to set unexported field password from variable [PASSWORD]")
+		So(actual.Host, ShouldEqual, "localhost")
+		So(actual.password, ShouldEqual, "")
+	})
+
+	Convey("structs that embed themselves are an error", t, func() {
+		src := WithSource(Map("test", map[string]string{"NAME": "a", "A": "1", "B": "2"}))
+
+		err := Parse(&TestRecursive{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse TestRecursive: env.TestRecursive embeds itself")
+		err = Check(&TestRecursive{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse TestRecursive: env.TestRecursive embeds itself")
+
+		err = Parse(&TestCycleA{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse TestCycleB.TestCycleA: env.TestCycleA embeds itself")
+		err = Check(&TestCycleA{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse TestCycleB.TestCycleA: env.TestCycleA embeds itself")
+	})
+}
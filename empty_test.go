@@ -0,0 +1,129 @@
+package env
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_emptyValues(t *testing.T) {
+	type TestStruct struct {
+		Name   string   `env:"NAME" default:"anonymous"`
+		Prefix string   `env:"PREFIX"`
+		Tags   []string `env:"TAGS" default:"a,b"`
+	}
+
+	src := Map("test", map[string]string{
+		"NAME":   "",
+		"PREFIX": "  > ",
+		"TAGS":   "",
+	})
+
+	Convey("empty values are unset by default", t, func() {
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Name:   "anonymous",
+			Prefix: ">",
+			Tags:   []string{"a", "b"},
+		}
+		err := Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("allowEmpty option", t, func() {
+		actual := &TestStruct{
+			Name: "prepopulated",
+		}
+		expected := &TestStruct{
+			Prefix: ">",
+		}
+		prov := Provenance{}
+		err := Parse(actual, WithSource(src), WithAllowEmpty(true), WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+		So(prov["Name"], ShouldEqual, "test")
+	})
+
+	Convey("allowEmpty tag", t, func() {
+		type TagStruct struct {
+			Name  string `env:"NAME" default:"anonymous" allowEmpty:"true" shared:"true"`
+			Other string `env:"NAME" default:"anonymous" shared:"true"`
+		}
+
+		actual := &TagStruct{}
+		expected := &TagStruct{
+			Other: "anonymous",
+		}
+		err := Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("notEmpty", t, func() {
+		type TagStruct struct {
+			Name string `env:"NAME" notEmpty:"true"`
+		}
+
+		err := Parse(&TagStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [NAME] is set but empty")
+
+		err = Parse(&TestStruct{}, WithSource(src), WithNotEmpty(true))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [TAGS] is set but empty")
+
+		// Unset variables are not affected
+		err = Parse(&TagStruct{}, WithSource(Map("test", nil)))
+		So(err, ShouldBeNil)
+	})
+
+	Convey("whitespace only values are empty once trimmed", t, func() {
+		type TagStruct struct {
+			Name string `env:"NAME" notEmpty:"true"`
+		}
+
+		err := Parse(&TagStruct{}, WithSource(Map("test", map[string]string{"NAME": "   "})))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("trim disabled", t, func() {
+		type TagStruct struct {
+			Prefix string   `env:"PREFIX" trim:"false"`
+			Spaces string   `env:"SPACES" trim:"false"`
+			List   []string `env:"LIST"`
+		}
+
+		values := Map("test", map[string]string{
+			"PREFIX": "  > ",
+			"SPACES": "   ",
+			"LIST":   " a , b ",
+		})
+
+		actual := &TagStruct{}
+		expected := &TagStruct{
+			Prefix: "  > ",
+			Spaces: "   ",
+			List:   []string{"a", "b"},
+		}
+		err := Parse(actual, WithSource(values))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+
+		actual = &TagStruct{}
+		expected.List = []string{" a ", " b "}
+		err = Parse(actual, WithSource(values), WithTrim(false))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("bad tag", t, func() {
+		type BadStruct struct {
+			Name string `env:"NAME" allowEmpty:"sure"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag allowEmpty")
+	})
+}
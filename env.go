@@ -3,19 +3,96 @@ package env
 import (
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
-
-	"github.com/pcman312/errutils"
 )
 
 var (
 	ErrNotStructPointer = errors.New("input must be a pointer to a struct")
 )
 
-func Parse(conf interface{}) error {
+// Option configures the behavior of Parse.
+type Option func(*parser)
+
+// WithSource makes Parse read variables from src instead of the process environment.
+func WithSource(src Source) Option {
+	return func(p *parser) {
+		p.source = src
+	}
+}
+
+// WithProvenance records, for each field that was populated, where its value came from.
+func WithProvenance(prov Provenance) Option {
+	return func(p *parser) {
+		p.provenance = prov
+	}
+}
+
+// WithTrim sets whether leading and trailing whitespace is trimmed from values
+// and slice elements. Defaults to true. The trim tag overrides it per field.
+func WithTrim(trim bool) Option {
+	return func(p *parser) {
+		p.trim = trim
+	}
+}
+
+// WithAllowEmpty sets whether a variable that is set to an empty value is used
+// as an empty value rather than treated as unset. Defaults to false. The
+// allowEmpty tag overrides it per field.
+func WithAllowEmpty(allowEmpty bool) Option {
+	return func(p *parser) {
+		p.allowEmpty = allowEmpty
+	}
+}
+
+// WithNotEmpty sets whether a variable that is set to an empty value is an
+// error. Defaults to false. The notEmpty tag overrides it per field.
+func WithNotEmpty(notEmpty bool) Option {
+	return func(p *parser) {
+		p.notEmpty = notEmpty
+	}
+}
+
+// WithFailFast sets whether Parse stops at the first error, both across fields
+// and across the elements of a list, instead of returning every error. Defaults
+// to false. The failFast tag overrides it for the elements of a field.
+func WithFailFast(failFast bool) Option {
+	return func(p *parser) {
+		p.failFast = failFast
+	}
+}
+
+// WithBoolValues adds words that are accepted as true and false by boolean fields,
+// such as "yes" and "no", in addition to the spellings accepted by
+// strconv.ParseBool. Words are matched case-insensitively. The truthy and falsy
+// tags add more words per field.
+func WithBoolValues(truthy, falsy []string) Option {
+	return func(p *parser) {
+		p.truthy = append(p.truthy, truthy...)
+		p.falsy = append(p.falsy, falsy...)
+	}
+}
+
+// Provenance maps the path of a parsed field (e.g. "DB.Host") to the name of the
+// source that supplied its value, or "default" if the default tag was used.
+type Provenance map[string]string
+
+type parser struct {
+	source     Source
+	provenance Provenance
+	vars       bindings
+	trim       bool
+	allowEmpty bool
+	notEmpty   bool
+	failFast   bool
+	truthy     []string
+	falsy      []string
+	// Struct types being parsed, so embedded pointers can't recurse forever
+	parsing map[reflect.Type]int
+}
+
+func Parse(conf interface{}, opts ...Option) error {
 	ptrRef := reflect.ValueOf(conf)
 	if ptrRef.Kind() != reflect.Ptr {
 		return ErrNotStructPointer
@@ -25,85 +102,213 @@ func Parse(conf interface{}) error {
 		return ErrNotStructPointer
 	}
 
-	return parseStruct(ref)
+	p := newParser(opts)
+	return p.parseStruct(ref, "", "")
 }
 
-func parseStruct(value reflect.Value) error {
+func newParser(opts []Option) *parser {
+	p := &parser{
+		source:  OS(),
+		vars:    bindings{},
+		trim:    true,
+		parsing: map[reflect.Type]int{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// parseStruct populates the fields of value. The prefix is prepended to the
+// variable name of each field and path is the field path of value itself.
+func (p *parser) parseStruct(value reflect.Value, prefix, path string) error {
 	t := value.Type()
+	p.parsing[t]++
+	defer func() { p.parsing[t]-- }()
+
 	errs := []error{}
 	for i := 0; i < value.NumField(); i++ {
-		err := handleField(value.Field(i), t.Field(i))
+		err := p.handleField(value.Field(i), t.Field(i), prefix, joinPath(path, t.Field(i).Name))
+		if err != nil && p.failFast {
+			return err
+		}
 		if err != nil {
 			errs = append(errs, err)
 		}
 	}
 
 	if len(errs) != 0 {
-		return errutils.JoinErrs(", ", errs...)
+		return joinErrs(errs)
 	}
 
 	return nil
 }
 
-func handleField(value reflect.Value, field reflect.StructField) error {
-	envName := field.Tag.Get("env")
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func (p *parser) handleField(value reflect.Value, field reflect.StructField, prefix, path string) error {
+	envName := strings.TrimSpace(field.Tag.Get("env"))
+	if envName == "-" {
+		return nil
+	}
+	if isEmbeddedStruct(field) {
+		return p.handleEmbedded(value, field, prefix, envName, path)
+	}
 	// Skip fields that do not have an env struct tag specified
-	if envName == "" || envName == "-" {
+	if envName == "" {
 		return nil
 	}
+	envName = prefix + envName
+	if !field.IsExported() {
+		err := fmt.Errorf("unable to set unexported field %s from variable [%s]: export the field or remove its env tag", path, envName)
+		return newFieldError(field, path, envName, "", err)
+	}
 
-	defaultVal := field.Tag.Get("default")
-	required, err := isRequired(field)
+	rules, err := p.getValueRules(field)
 	if err != nil {
-		return err
+		return newFieldError(field, path, envName, "", err)
+	}
+
+	format := field.Tag.Get("format")
+	if isStructSlice(field.Type) && format != "json" {
+		return p.handleStructSlice(value, field, envName, path, rules.required)
+	}
+	if field.Type.Kind() == reflect.Interface && format != "json" {
+		return p.handleVariant(value, field, envName, path, rules)
 	}
 
-	rawVal, err := getFieldValue(envName, defaultVal, required)
+	err = p.vars.bind(envName, path, field)
 	if err != nil {
-		return err
+		return newFieldError(field, path, envName, "", err)
 	}
 
-	err = parseField(value, field, rawVal)
+	rawVal, origin, err := p.getFieldValue(envName, rules)
 	if err != nil {
-		return err
+		return newFieldError(field, path, envName, "", err)
+	}
+
+	switch {
+	case rawVal == "" && origin != "":
+		// The variable was deliberately set to an empty value
+		value.Set(reflect.Zero(value.Type()))
+	case format == "json":
+		err = handleJSON(value, describeVar(envName, origin), rawVal)
+	default:
+		err = p.parseField(value, field, envName, rawVal)
+	}
+	if err != nil {
+		return newFieldError(field, path, envName, rawVal, err)
 	}
 
+	if p.provenance != nil && origin != "" {
+		p.provenance[path] = origin
+	}
 	return nil
 }
 
-func getFieldValue(envName, defaultVal string, required bool) (string, error) {
-	envName = strings.TrimSpace(envName)
+// valueRules control how the raw value of a field is looked up.
+type valueRules struct {
+	defaultVal string
+	required   bool
+	trim       bool
+	allowEmpty bool
+	notEmpty   bool
+	truthy     []string
+	falsy      []string
+}
+
+func (p *parser) getValueRules(field reflect.StructField) (valueRules, error) {
+	rules := valueRules{
+		defaultVal: field.Tag.Get("default"),
+	}
 
-	// Get value from environment
-	rawValue := strings.TrimSpace(os.Getenv(envName))
+	tags := []struct {
+		name       string
+		dest       *bool
+		defaultVal bool
+	}{
+		{"required", &rules.required, false},
+		{"trim", &rules.trim, p.trim},
+		{"allowEmpty", &rules.allowEmpty, p.allowEmpty},
+		{"notEmpty", &rules.notEmpty, p.notEmpty},
+	}
+	for _, tag := range tags {
+		val, err := getBoolTag(field, tag.name, tag.defaultVal)
+		if err != nil {
+			return rules, fmt.Errorf("unable to parse tag %s on %s: %s", tag.name, field.Name, err)
+		}
+		*tag.dest = val
+	}
+
+	return rules, nil
+}
+
+// getFieldValue returns the raw value of the variable along with the name of
+// the source it came from. The origin is empty if no value was found, so an
+// empty value with a non-empty origin means the variable was set to empty and
+// the field allows that.
+func (p *parser) getFieldValue(envName string, rules valueRules) (string, string, error) {
+	// Get value from the source
+	rawValue, origin, exists := lookup(p.source, envName, rules.trim, rules.allowEmpty)
 	if rawValue != "" {
-		return rawValue, nil
+		return rawValue, origin, nil
 	}
 
-	// No value in environment found
-	if defaultVal == "" && required {
-		return "", fmt.Errorf("missing required variable [%s]", envName)
+	if exists {
+		if rules.notEmpty {
+			return "", "", fmt.Errorf("variable [%s] is set but empty", envName)
+		}
+		if rules.allowEmpty {
+			return "", origin, nil
+		}
 	}
-	return defaultVal, nil
+
+	// No value in the source found
+	if rules.defaultVal == "" {
+		if rules.required {
+			return "", "", fmt.Errorf("missing required variable [%s]", envName)
+		}
+		return "", "", nil
+	}
+	return rules.defaultVal, "default", nil
+}
+
+// describeVar names the variable a value came from for use in error messages.
+func describeVar(envName, origin string) string {
+	if origin == "default" {
+		return fmt.Sprintf("default value of [%s]", envName)
+	}
+	return fmt.Sprintf("variable [%s]", envName)
 }
 
-func isRequired(field reflect.StructField) (bool, error) {
-	rawReq := strings.TrimSpace(field.Tag.Get("required"))
-	if rawReq == "" {
-		return false, nil
+func getBoolTag(field reflect.StructField, tag string, defaultVal bool) (bool, error) {
+	rawVal := strings.TrimSpace(field.Tag.Get(tag))
+	if rawVal == "" {
+		return defaultVal, nil
 	}
-	return strconv.ParseBool(rawReq)
+	return strconv.ParseBool(rawVal)
 }
 
-func parseField(value reflect.Value, field reflect.StructField, rawVal string) error {
+func (p *parser) parseField(value reflect.Value, field reflect.StructField, envName, rawVal string) error {
 	switch field.Type.Kind() {
 	case reflect.Bool:
-		return handleBool(value, rawVal)
+		return handleBool(value, p.getBoolVocabulary(field), rawVal)
 
 	case reflect.String:
-		return handleString(value, rawVal)
+		if field.Type == formatType {
+			return handleLogFormat(value, field, rawVal)
+		}
+		return handleString(value, field, rawVal)
 
 	case reflect.Int8, reflect.Int16, reflect.Int, reflect.Int32, reflect.Int64:
+		if field.Type == levelType {
+			return handleLevel(value, field, rawVal)
+		}
 		return handleInt(value, field, rawVal)
 
 	case reflect.Uint8, reflect.Uint16, reflect.Uint, reflect.Uint32, reflect.Uint64:
@@ -113,32 +318,51 @@ func parseField(value reflect.Value, field reflect.StructField, rawVal string) e
 		return handleFloat(value, field, rawVal)
 
 	case reflect.Slice:
-		return handleSlice(value, field, rawVal)
+		if field.Type == ipType {
+			return handleNetValue(value, field, rawVal)
+		}
+		if hasEncoding(field) {
+			return handleBytes(value, field, rawVal)
+		}
+		return p.handleSlice(value, field, envName, rawVal)
+
+	case reflect.Array:
+		if hasEncoding(field) {
+			return handleBytes(value, field, rawVal)
+		}
+		return p.handleArray(value, field, envName, rawVal)
 
 	case reflect.Ptr:
-		return handlePointer(value, field, rawVal)
+		return handlePointer(value, field, envName, rawVal)
+
+	case reflect.Struct:
+		return handleStruct(value, field, rawVal)
 	}
 
 	return fmt.Errorf("unsupported type %s", field.Type.Kind())
 }
 
-func handleSlice(value reflect.Value, field reflect.StructField, rawVal string) error {
-	delim := getSliceDelim(field)
-	arr := make([]string, 0)
-	if rawVal != "" {
-		arr = strings.Split(rawVal, delim)
-
-		for i, str := range arr {
-			arr[i] = strings.TrimSpace(str)
-		}
+func (p *parser) handleSlice(value reflect.Value, field reflect.StructField, envName, rawVal string) error {
+	trim, err := getBoolTag(field, "trim", p.trim)
+	if err != nil {
+		return fmt.Errorf("unable to parse tag trim on %s: %s", field.Name, err)
+	}
+	failFast, err := getBoolTag(field, "failFast", p.failFast)
+	if err != nil {
+		return fmt.Errorf("unable to parse tag failFast on %s: %s", field.Name, err)
 	}
+	raw, err := splitList(field, rawVal, trim)
+	if err != nil {
+		return err
+	}
+	arr := listElements{envName: envName, raw: raw, failFast: failFast}
 
 	switch value.Type() {
 	case sliceOfBools:
-		return handleBoolSlice(value, arr)
+		return handleBoolSlice(value, p.getBoolVocabulary(field), arr)
 
 	case sliceOfStrings:
-		return handleStringSlice(value, arr)
+		return handleStringSlice(value, field, arr)
 
 	case sliceOfInt8s, sliceOfInt16s, sliceOfInt32s, sliceOfInts, sliceOfInt64s, sliceOfDurations:
 		return handleIntSlice(value, field, arr)
@@ -149,28 +373,73 @@ func handleSlice(value reflect.Value, field reflect.StructField, rawVal string)
 	case sliceOfFloat32s, sliceOfFloat64s:
 		return handleFloatSlice(value, field, arr)
 
-	case sliceOfUrlPointers:
-		return handleUrlSlice(value, arr)
+	case sliceOfUrlPointers, sliceOfUrls:
+		return handleUrlSlice(value, field, arr)
+
+	case sliceOfTimes:
+		return handleTimeSlice(value, field, arr)
+
+	case sliceOfLocationPointers:
+		return handleLocationSlice(value, arr)
+
+	case sliceOfIPs, sliceOfIPNetPointers, sliceOfAddrs, sliceOfPrefixes, sliceOfAddrPorts:
+		return handleNetSlice(value, field, arr)
 
 	default:
 		return fmt.Errorf("unsupported slice type %s", field.Type.Elem().Kind())
 	}
 }
 
-func getSliceDelim(field reflect.StructField) string {
-	delim, delimExists := field.Tag.Lookup("delimiter")
-	if !delimExists {
-		delim = ","
+// handleArray parses a fixed-size array by parsing the value as a slice of the
+// same element type, which must have exactly as many elements as the array.
+func (p *parser) handleArray(value reflect.Value, field reflect.StructField, envName, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+
+	sliceField := field
+	sliceField.Type = reflect.SliceOf(field.Type.Elem())
+	slice := reflect.New(sliceField.Type).Elem()
+	err := p.handleSlice(slice, sliceField, envName, rawVal)
+	if err != nil {
+		return err
 	}
 
-	return delim
+	if slice.Len() != value.Len() {
+		return fmt.Errorf("%s must have exactly %d elements, got %d", field.Name, value.Len(), slice.Len())
+	}
+	reflect.Copy(value, slice)
+	return nil
 }
 
-func handlePointer(value reflect.Value, field reflect.StructField, rawVal string) error {
+func handlePointer(value reflect.Value, field reflect.StructField, envName, rawVal string) error {
 	switch field.Type.Elem() {
 	case urlType:
-		return handleUrl(value, rawVal)
+		return handleUrl(value, field, rawVal)
+	case locationType:
+		return handleLocation(value, rawVal)
+	case ipNetType:
+		return handleNetValue(value, field, rawVal)
+	case regexpType:
+		return handleRegexp(value, envName, rawVal)
+	case templateType:
+		return handleTemplate(value, envName, rawVal)
+	case bigIntType, bigFloatType, bigRatType:
+		return handleBigNumber(value, field, rawVal)
 	default:
 		return fmt.Errorf("unsupported pointer type %s", field.Type.Elem().Kind())
 	}
 }
+
+func handleStruct(value reflect.Value, field reflect.StructField, rawVal string) error {
+	switch field.Type {
+	case urlType:
+		return handleUrl(value, field, rawVal)
+	case timeType:
+		return handleTime(value, field, rawVal)
+	case addrType, prefixType, addrPortType:
+		return handleNetValue(value, field, rawVal)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type.Kind())
+	}
+}
@@ -85,11 +85,8 @@ func TestParse(t *testing.T) {
 	})
 
 	Convey("Unsupported slice", t, func() {
-		type MyStruct struct {
-			Str string
-		}
 		type BadStruct struct {
-			UnsupportedSlice []MyStruct `env:"unsupportedslice"`
+			UnsupportedSlice []map[string]string `env:"unsupportedslice"`
 		}
 
 		actual := &BadStruct{}
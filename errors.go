@@ -0,0 +1,197 @@
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const redacted = "[redacted]"
+
+// FieldError is a problem with a single field. Every error Parse finds with a
+// field is a *FieldError, and its message always has the same format:
+//
+//	Upstreams[0].Port (UPSTREAM_0_PORT="http"): strconv.ParseInt: parsing "http": invalid syntax
+//
+// The values of fields tagged secret:"true", and of encoded byte fields, are
+// redacted from the message. When several fields have problems, Parse returns
+// their messages joined with ", ", and errors.As finds each of them.
+type FieldError struct {
+	// Path is the path of the field in the struct, such as Upstreams[0].Port
+	Path string
+	// Variable is the name of the variable the field reads, if it has one
+	Variable string
+	// Value is the value that couldn't be used, or "" if there wasn't one or the
+	// field is secret
+	Value string
+	// Redacted is set if the field is secret and had a value
+	Redacted bool
+	// Err is the underlying error. Its message is not redacted
+	Err error
+
+	msg string
+}
+
+func (e *FieldError) Error() string {
+	switch {
+	case e.Variable == "":
+		return fmt.Sprintf("%s: %s", e.Path, e.msg)
+	case e.Redacted:
+		return fmt.Sprintf("%s (%s=%s): %s", e.Path, e.Variable, redacted, e.msg)
+	case e.Value != "":
+		return fmt.Sprintf("%s (%s=%q): %s", e.Path, e.Variable, e.Value, e.msg)
+	default:
+		return fmt.Sprintf("%s (%s): %s", e.Path, e.Variable, e.msg)
+	}
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// errorList is several errors reported together. Its message joins theirs with
+// ", ", and errors.Is and errors.As look through each of them.
+type errorList []error
+
+func (e errorList) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, ", ")
+}
+
+func (e errorList) Unwrap() []error {
+	return e
+}
+
+// joinErrs returns errs as a single error, or nil if there are none.
+func joinErrs(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errorList(errs)
+	}
+}
+
+// newFieldError wraps err with the field it came from, redacting rawVal if the
+// field is secret. Errors that are already a *FieldError are returned as is.
+func newFieldError(field reflect.StructField, path, envName, rawVal string, err error) error {
+	if fieldErr, ok := err.(*FieldError); ok {
+		return fieldErr
+	}
+
+	fieldErr := &FieldError{
+		Path:     path,
+		Variable: envName,
+		Value:    rawVal,
+		Err:      err,
+		msg:      err.Error(),
+	}
+	switch {
+	case rawVal == "":
+		// Nothing to redact
+	case isSecret(field):
+		fieldErr.Value = ""
+		fieldErr.Redacted = true
+		fieldErr.msg = redact(fieldErr.msg, field, rawVal)
+	case isURLField(field):
+		fieldErr.Value = redactURLs(field, rawVal)
+	}
+	return fieldErr
+}
+
+// isSecret reports whether the value of field must not appear in errors. Fields
+// with an encoding tag are secret unless tagged otherwise, since they usually
+// hold keys. A secret tag that doesn't parse is treated as true to be safe.
+func isSecret(field reflect.StructField) bool {
+	secret, err := getBoolTag(field, "secret", hasEncoding(field))
+	return secret || err != nil
+}
+
+func isURLField(field reflect.StructField) bool {
+	t := field.Type
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t == urlType || (t.Kind() == reflect.Ptr && t.Elem() == urlType)
+}
+
+// redactURLs replaces the passwords of the URLs in rawVal with "xxxxx".
+func redactURLs(field reflect.StructField, rawVal string) string {
+	elems := []string{rawVal}
+	if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
+		elems, _ = splitList(field, rawVal, true)
+	}
+	for _, elem := range elems {
+		u, err := url.Parse(elem)
+		if err != nil {
+			rawVal = strings.Replace(rawVal, elem, redactUserinfo(elem), 1)
+			continue
+		}
+		if u.User == nil {
+			continue
+		}
+		if _, hasPassword := u.User.Password(); hasPassword {
+			rawVal = strings.Replace(rawVal, elem, u.Redacted(), 1)
+		}
+	}
+	return rawVal
+}
+
+// redactUserinfo replaces the password in a URL that doesn't parse, so the
+// password of a URL with a bad port or host doesn't end up in an error.
+func redactUserinfo(rawURL string) string {
+	start := strings.Index(rawURL, "://")
+	if start < 0 {
+		return rawURL
+	}
+	start += len("://")
+
+	authority := rawURL[start:]
+	if end := strings.IndexAny(authority, "/?#"); end >= 0 {
+		authority = authority[:end]
+	}
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return rawURL
+	}
+	colon := strings.Index(authority[:at], ":")
+	if colon < 0 {
+		return rawURL
+	}
+	return rawURL[:start+colon+1] + "xxxxx" + rawURL[start+at:]
+}
+
+// redact replaces the value, and each of its list elements, in msg. Messages
+// often quote the value with %q, so the escaped forms are replaced as well.
+func redact(msg string, field reflect.StructField, rawVal string) string {
+	elems := []string{rawVal}
+	if split, err := splitList(field, rawVal, true); err == nil && len(split) > 1 {
+		elems = append(elems, split...)
+	}
+	values := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+		quoted := strconv.Quote(elem)
+		ascii := strconv.QuoteToASCII(elem)
+		values = append(values, elem, quoted[1:len(quoted)-1], ascii[1:len(ascii)-1])
+	}
+	// Replace longer values first so an element doesn't break up the whole value
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	for _, val := range values {
+		msg = strings.ReplaceAll(msg, val, redacted)
+	}
+	return msg
+}
@@ -2,6 +2,7 @@ package env
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 )
@@ -24,35 +25,116 @@ func parseFloat(field reflect.StructField, rawVal string) (float64, error) {
 		return 0, err
 	}
 
-	f, err := strconv.ParseFloat(rawVal, size)
+	f, err := parseFloatString(field, rawVal, size)
 	if err != nil {
 		return 0, err
 	}
 
+	// NaN and infinities are rejected unless allowed, since NaN passes every bounds check
+	allowNaN, err := getBoolTag(field, "allowNaN", false)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse tag allowNaN on %s: %s", field.Name, err)
+	}
+	if !allowNaN && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return 0, fmt.Errorf("%s must be a finite number", field.Name)
+	}
+
+	// Percentages are ratios, so they default to the range 0..1
+	defaultMin, defaultMax := minFloats[size], maxFloats[size]
+	switch {
+	case isPercent(field):
+		defaultMin, defaultMax = 0, 1
+	case allowNaN:
+		defaultMin, defaultMax = math.Inf(-1), math.Inf(1)
+	}
+
 	// Get min/max values to check against
-	min, err := getFloatTag(field, "min", minFloats[size], size)
+	min, err := getFloatTag(field, "min", defaultMin, size)
 	if err != nil {
 		return 0, err
 	}
-	max, err := getFloatTag(field, "max", maxFloats[size], size)
+	max, err := getFloatTag(field, "max", defaultMax, size)
 	if err != nil {
 		return 0, err
 	}
 
 	if f < min {
-		return 0, fmt.Errorf("%s must be at least %f", field.Name, min)
+		return 0, fmt.Errorf("%s must be at least %s%s", field.Name, formatFloat(field, min, size), ratioHint(field, rawVal, f, size))
 	}
 	if f > max {
-		return 0, fmt.Errorf("%s must be no more than %f", field.Name, max)
+		return 0, fmt.Errorf("%s must be no more than %s%s", field.Name, formatFloat(field, max, size), ratioHint(field, rawVal, f, size))
+	}
+
+	err = checkFloatConstraints(field, rawVal, f, size)
+	if err != nil {
+		return 0, err
 	}
 
 	return f, nil
 }
 
+// checkFloatConstraints checks f against the exclusiveMin, exclusiveMax and
+// multipleOf tags, which have no effect unless they are set. Since most decimal
+// fractions can't be represented exactly, multipleOf allows for rounding error.
+func checkFloatConstraints(field reflect.StructField, rawVal string, f float64, size int) error {
+	if _, exists := field.Tag.Lookup("exclusiveMin"); exists {
+		exclusiveMin, err := getFloatTag(field, "exclusiveMin", 0, size)
+		if err != nil {
+			return err
+		}
+		if f <= exclusiveMin {
+			return fmt.Errorf("%s must be greater than %s%s", field.Name, formatFloat(field, exclusiveMin, size), ratioHint(field, rawVal, f, size))
+		}
+	}
+	if _, exists := field.Tag.Lookup("exclusiveMax"); exists {
+		exclusiveMax, err := getFloatTag(field, "exclusiveMax", 0, size)
+		if err != nil {
+			return err
+		}
+		if f >= exclusiveMax {
+			return fmt.Errorf("%s must be less than %s%s", field.Name, formatFloat(field, exclusiveMax, size), ratioHint(field, rawVal, f, size))
+		}
+	}
+	if _, exists := field.Tag.Lookup("multipleOf"); exists {
+		multipleOf, err := getFloatTag(field, "multipleOf", 0, size)
+		if err != nil {
+			return err
+		}
+		if multipleOf == 0 || math.IsNaN(multipleOf) || math.IsInf(multipleOf, 0) {
+			return fmt.Errorf("unable to parse tag multipleOf on %s: must be a finite, non-zero number", field.Name)
+		}
+		// float32 values and tags only have about 7 significant digits
+		tolerance := 1e-9
+		if size == 32 {
+			tolerance = 1e-6
+		}
+		quotient := f / multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > tolerance*math.Max(1, math.Abs(quotient)) {
+			if isPercent(field) {
+				return fmt.Errorf("%s must be a multiple of %s", field.Name, formatFloat(field, multipleOf, size))
+			}
+			return fmt.Errorf("%s must be a multiple of %g", field.Name, multipleOf)
+		}
+	}
+	return nil
+}
+
+// parseFloatString parses a float in the notation selected by the unit tag.
+func parseFloatString(field reflect.StructField, rawVal string, size int) (float64, error) {
+	switch unit := field.Tag.Get("unit"); unit {
+	case "":
+		return strconv.ParseFloat(rawVal, size)
+	case "percent":
+		return parsePercent(rawVal, size)
+	default:
+		return 0, fmt.Errorf("unsupported unit %q on %s", unit, field.Name)
+	}
+}
+
 func getFloatTag(field reflect.StructField, tag string, defaultVal float64, size int) (float64, error) {
 	rawVal, minExists := field.Tag.Lookup(tag)
 	if minExists {
-		parsedVal, err := strconv.ParseFloat(rawVal, size)
+		parsedVal, err := parseFloatString(field, rawVal, size)
 		if err != nil {
 			return 0, fmt.Errorf("unable to parse tag %s on %s: %s", tag, field.Name, err)
 		}
@@ -61,8 +143,8 @@ func getFloatTag(field reflect.StructField, tag string, defaultVal float64, size
 	return defaultVal, nil
 }
 
-func handleFloatSlice(ref reflect.Value, field reflect.StructField, rawArr []string) error {
-	if len(rawArr) == 0 {
+func handleFloatSlice(ref reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
 		return nil
 	}
 	var arr interface{}
@@ -71,9 +153,9 @@ func handleFloatSlice(ref reflect.Value, field reflect.StructField, rawArr []str
 	t := ref.Type()
 	switch t {
 	case sliceOfFloat32s:
-		arr, err = getFloat32Slice(field, rawArr)
+		arr, err = getFloat32Slice(field, elems)
 	case sliceOfFloat64s:
-		arr, err = getFloat64Slice(field, rawArr)
+		arr, err = getFloat64Slice(field, elems)
 	}
 
 	if err != nil {
@@ -84,26 +166,22 @@ func handleFloatSlice(ref reflect.Value, field reflect.StructField, rawArr []str
 	return nil
 }
 
-func getFloat32Slice(field reflect.StructField, split []string) ([]float32, error) {
-	arr := make([]float32, len(split), len(split))
-	for i, raw := range split {
+func getFloat32Slice(field reflect.StructField, elems listElements) ([]float32, error) {
+	arr := make([]float32, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseFloat(field, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = float32(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getFloat64Slice(structField reflect.StructField, split []string) ([]float64, error) {
-	arr := make([]float64, len(split), len(split))
-	for i, raw := range split {
+func getFloat64Slice(structField reflect.StructField, elems listElements) ([]float64, error) {
+	arr := make([]float64, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseFloat(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = val
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
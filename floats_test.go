@@ -172,3 +172,100 @@ func getRandFloat(size int) float64 {
 		panic("invalid size")
 	}
 }
+
+func TestParse_floatConstraints(t *testing.T) {
+	Convey("NaN and infinities", t, func() {
+		type TestStruct struct {
+			Ratio   float64 `env:"RATIO" max:"1"`
+			Scale   float32 `env:"SCALE" allowNaN:"true"`
+			Clamped float64 `env:"CLAMPED" allowNaN:"true" min:"0"`
+		}
+
+		for _, value := range []string{"NaN", "nan", "Inf", "+Inf", "-Inf", "infinity"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATIO": value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Ratio must be a finite number")
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"SCALE": "NaN"})))
+		So(err, ShouldBeNil)
+		So(math.IsNaN(float64(actual.Scale)), ShouldBeTrue)
+
+		err = Parse(actual, WithSource(Map("test", map[string]string{"SCALE": "-Inf"})))
+		So(err, ShouldBeNil)
+		So(math.IsInf(float64(actual.Scale), -1), ShouldBeTrue)
+
+		err = Parse(actual, WithSource(Map("test", map[string]string{"CLAMPED": "+Inf"})))
+		So(err, ShouldBeNil)
+		So(math.IsInf(actual.Clamped, 1), ShouldBeTrue)
+
+		err = Parse(actual, WithSource(Map("test", map[string]string{"CLAMPED": "-Inf"})))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("exclusive bounds", t, func() {
+		type TestStruct struct {
+			Rate float64 `env:"RATE" exclusiveMin:"0" exclusiveMax:"1"`
+		}
+
+		tests := map[string]bool{
+			"0":     false,
+			"0.001": true,
+			"0.999": true,
+			"1":     false,
+			"-0.5":  false,
+		}
+
+		for value, pass := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": value})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": "0"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Rate must be greater than 0")
+	})
+
+	Convey("multipleOf", t, func() {
+		type TestStruct struct {
+			Step  float64   `env:"STEP" multipleOf:"0.1"`
+			Steps []float32 `env:"STEPS" multipleOf:"0.25"`
+			Ratio float32   `env:"RATIO" multipleOf:"0.05"`
+		}
+
+		tests := map[string]bool{
+			"RATIO=0.45":         true,
+			"RATIO=0.95":         true,
+			"RATIO=123.45":       true,
+			"RATIO=0.46":         false,
+			"STEP=0.3":           true,
+			"STEP=-1.7":          true,
+			"STEP=12345.6":       true,
+			"STEP=0.35":          false,
+			"STEPS=0.25,1.5,-2":  true,
+			"STEPS=0.25,0.3,0.5": false,
+		}
+
+		for keyVal, pass := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		type ZeroStruct struct {
+			Step float64 `env:"STEP" multipleOf:"0"`
+		}
+		err := Parse(&ZeroStruct{}, WithSource(Map("test", map[string]string{"STEP": "1"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag multipleOf")
+	})
+}
@@ -37,7 +37,7 @@ func parseInt(field reflect.StructField, rawVal string) (int64, error) {
 		return 0, err
 	}
 
-	i, err := strconv.ParseInt(rawVal, 10, size)
+	i, err := parseIntString(field, rawVal, size)
 	if err != nil {
 		return 0, err
 	}
@@ -59,13 +59,75 @@ func parseInt(field reflect.StructField, rawVal string) (int64, error) {
 		return 0, fmt.Errorf("%s must be no more than %d", field.Name, max)
 	}
 
+	err = checkIntConstraints(field, i, size)
+	if err != nil {
+		return 0, err
+	}
+
 	return i, nil
 }
 
+// checkIntConstraints checks i against the exclusiveMin, exclusiveMax and
+// multipleOf tags, which have no effect unless they are set.
+func checkIntConstraints(field reflect.StructField, i int64, size int) error {
+	if _, exists := field.Tag.Lookup("exclusiveMin"); exists {
+		exclusiveMin, err := getIntTag(field, "exclusiveMin", 0, size)
+		if err != nil {
+			return err
+		}
+		if i <= exclusiveMin {
+			return fmt.Errorf("%s must be greater than %d", field.Name, exclusiveMin)
+		}
+	}
+	if _, exists := field.Tag.Lookup("exclusiveMax"); exists {
+		exclusiveMax, err := getIntTag(field, "exclusiveMax", 0, size)
+		if err != nil {
+			return err
+		}
+		if i >= exclusiveMax {
+			return fmt.Errorf("%s must be less than %d", field.Name, exclusiveMax)
+		}
+	}
+	if _, exists := field.Tag.Lookup("multipleOf"); exists {
+		multipleOf, err := getIntTag(field, "multipleOf", 0, size)
+		if err != nil {
+			return err
+		}
+		if multipleOf == 0 {
+			return fmt.Errorf("unable to parse tag multipleOf on %s: must not be zero", field.Name)
+		}
+		if i%multipleOf != 0 {
+			return fmt.Errorf("%s must be a multiple of %d", field.Name, multipleOf)
+		}
+	}
+	return nil
+}
+
+// parseIntString parses an integer in the notation selected by the unit and base tags.
+func parseIntString(field reflect.StructField, rawVal string, size int) (int64, error) {
+	if hasIntUnit(field) {
+		n, err := parseIntUnits(field, rawVal)
+		if err != nil {
+			return 0, err
+		}
+		return bigToInt64(n, size)
+	}
+
+	base, err := getBase(field)
+	if err != nil {
+		return 0, err
+	}
+	digits, err := normalizeDigits(rawVal, base)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(digits, base, size)
+}
+
 func getIntTag(structField reflect.StructField, tag string, defaultVal int64, size int) (int64, error) {
 	rawVal, minExists := structField.Tag.Lookup(tag)
 	if minExists {
-		parsedVal, err := strconv.ParseInt(rawVal, 10, size)
+		parsedVal, err := parseIntString(structField, rawVal, size)
 		if err != nil {
 			return 0, fmt.Errorf("unable to parse tag %s on %s: %s", tag, structField.Name, err)
 		}
@@ -75,7 +137,7 @@ func getIntTag(structField reflect.StructField, tag string, defaultVal int64, si
 }
 
 func parseDuration(structField reflect.StructField, rawVal string) (time.Duration, error) {
-	dur, err := time.ParseDuration(rawVal)
+	dur, err := parseDurationString(structField, rawVal)
 	if err != nil {
 		return 0, err
 	}
@@ -103,7 +165,7 @@ func parseDuration(structField reflect.StructField, rawVal string) (time.Duratio
 func getDurationTag(structField reflect.StructField, tag string, defaultVal time.Duration) (time.Duration, error) {
 	rawVal, minExists := structField.Tag.Lookup(tag)
 	if minExists {
-		parsedVal, err := time.ParseDuration(rawVal)
+		parsedVal, err := parseDurationString(structField, rawVal)
 		if err != nil {
 			return 0, fmt.Errorf("unable to parse tag %s on %s: %s", tag, structField.Name, err)
 		}
@@ -112,8 +174,8 @@ func getDurationTag(structField reflect.StructField, tag string, defaultVal time
 	return defaultVal, nil
 }
 
-func handleIntSlice(ref reflect.Value, field reflect.StructField, rawArr []string) error {
-	if len(rawArr) == 0 {
+func handleIntSlice(ref reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
 		return nil
 	}
 	var arr interface{}
@@ -122,17 +184,17 @@ func handleIntSlice(ref reflect.Value, field reflect.StructField, rawArr []strin
 	t := ref.Type()
 	switch t {
 	case sliceOfInt8s:
-		arr, err = getInt8Slice(field, rawArr)
+		arr, err = getInt8Slice(field, elems)
 	case sliceOfInt16s:
-		arr, err = getInt16Slice(field, rawArr)
+		arr, err = getInt16Slice(field, elems)
 	case sliceOfInt32s:
-		arr, err = getInt32Slice(field, rawArr)
+		arr, err = getInt32Slice(field, elems)
 	case sliceOfInt64s:
-		arr, err = getInt64Slice(field, rawArr)
+		arr, err = getInt64Slice(field, elems)
 	case sliceOfInts:
-		arr, err = getIntSlice(field, rawArr)
+		arr, err = getIntSlice(field, elems)
 	case sliceOfDurations:
-		arr, err = getDurationSlice(field, rawArr)
+		arr, err = getDurationSlice(field, elems)
 	}
 
 	if err != nil {
@@ -143,74 +205,62 @@ func handleIntSlice(ref reflect.Value, field reflect.StructField, rawArr []strin
 	return nil
 }
 
-func getInt8Slice(structField reflect.StructField, split []string) ([]int8, error) {
-	arr := make([]int8, len(split), len(split))
-	for i, raw := range split {
+func getInt8Slice(structField reflect.StructField, elems listElements) ([]int8, error) {
+	arr := make([]int8, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseInt(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = int8(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getInt16Slice(structField reflect.StructField, split []string) ([]int16, error) {
-	arr := make([]int16, len(split), len(split))
-	for i, raw := range split {
+func getInt16Slice(structField reflect.StructField, elems listElements) ([]int16, error) {
+	arr := make([]int16, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseInt(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = int16(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getInt32Slice(structField reflect.StructField, split []string) ([]int32, error) {
-	arr := make([]int32, len(split), len(split))
-	for i, raw := range split {
+func getInt32Slice(structField reflect.StructField, elems listElements) ([]int32, error) {
+	arr := make([]int32, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseInt(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = int32(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getInt64Slice(structField reflect.StructField, split []string) ([]int64, error) {
-	arr := make([]int64, len(split), len(split))
-	for i, raw := range split {
+func getInt64Slice(structField reflect.StructField, elems listElements) ([]int64, error) {
+	arr := make([]int64, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseInt(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = val
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getIntSlice(structField reflect.StructField, split []string) ([]int, error) {
-	arr := make([]int, len(split), len(split))
-	for i, raw := range split {
+func getIntSlice(structField reflect.StructField, elems listElements) ([]int, error) {
+	arr := make([]int, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseInt(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = int(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getDurationSlice(structField reflect.StructField, rawArr []string) ([]time.Duration, error) {
-	arr := make([]time.Duration, len(rawArr), len(rawArr))
-	for i, raw := range rawArr {
+func getDurationSlice(structField reflect.StructField, elems listElements) ([]time.Duration, error) {
+	arr := make([]time.Duration, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseDuration(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = val
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -295,3 +296,89 @@ func testInt(field string, size int) {
 	So(err, ShouldBeNil)
 	So(actual, ShouldResemble, expected)
 }
+
+func TestParse_intConstraints(t *testing.T) {
+	Convey("exclusive bounds", t, func() {
+		type TestStruct struct {
+			Workers int    `env:"WORKERS" exclusiveMin:"0" exclusiveMax:"64"`
+			Retries uint16 `env:"RETRIES" exclusiveMin:"1" exclusiveMax:"10"`
+		}
+
+		tests := map[string]bool{
+			"WORKERS=0":  false,
+			"WORKERS=1":  true,
+			"WORKERS=63": true,
+			"WORKERS=64": false,
+			"RETRIES=1":  false,
+			"RETRIES=2":  true,
+			"RETRIES=9":  true,
+			"RETRIES=10": false,
+		}
+
+		for keyVal, pass := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"WORKERS": "64"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Workers must be less than 64")
+	})
+
+	Convey("multipleOf", t, func() {
+		type TestStruct struct {
+			Offset int64  `env:"OFFSET" multipleOf:"-15"`
+			Size   uint32 `env:"SIZE" unit:"bytes" multipleOf:"4KiB"`
+			Ports  []int  `env:"PORTS" multipleOf:"10"`
+		}
+
+		tests := map[string]bool{
+			"OFFSET=45":    true,
+			"OFFSET=-30":   true,
+			"OFFSET=0":     true,
+			"OFFSET=20":    false,
+			"SIZE=8KiB":    true,
+			"SIZE=4097":    false,
+			"PORTS=80,90":  true,
+			"PORTS=80,443": false,
+		}
+
+		for keyVal, pass := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"SIZE": "4097"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Size must be a multiple of 4096")
+	})
+
+	Convey("bad constraint tags", t, func() {
+		type ZeroStruct struct {
+			Count uint `env:"COUNT" multipleOf:"0"`
+		}
+		type NonNumericStruct struct {
+			Count int `env:"COUNT" exclusiveMax:"many"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"COUNT": "1"}))
+
+		err := Parse(&ZeroStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag multipleOf")
+
+		err = Parse(&NonNumericStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag exclusiveMax")
+	})
+}
@@ -0,0 +1,38 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// handleJSON decodes rawVal into value with encoding/json, which allows fields of
+// any type that JSON can represent such as structs, maps and slices of structs.
+func handleJSON(value reflect.Value, desc, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+
+	target := reflect.New(value.Type())
+	err := json.Unmarshal([]byte(rawVal), target.Interface())
+	if err != nil {
+		return jsonError(desc, err)
+	}
+
+	value.Set(target.Elem())
+	return nil
+}
+
+func jsonError(desc string, err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return fmt.Errorf("invalid JSON in %s at offset %d: %s", desc, e.Offset, e)
+	case *json.UnmarshalTypeError:
+		if e.Field != "" {
+			return fmt.Errorf("invalid JSON in %s at offset %d: cannot use %s as %s in field %s", desc, e.Offset, e.Value, e.Type, e.Field)
+		}
+		return fmt.Errorf("invalid JSON in %s at offset %d: cannot use %s as %s", desc, e.Offset, e.Value, e.Type)
+	default:
+		return fmt.Errorf("invalid JSON in %s: %s", desc, err)
+	}
+}
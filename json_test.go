@@ -0,0 +1,80 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_json(t *testing.T) {
+	type Retry struct {
+		Attempts int    `json:"attempts"`
+		Backoff  string `json:"backoff"`
+	}
+	type Route struct {
+		Path    string `json:"path"`
+		Backend string `json:"backend"`
+	}
+
+	type TestStruct struct {
+		Retry   Retry             `env:"RETRY" format:"json"`
+		Routes  []Route           `env:"ROUTES" format:"json"`
+		Weights map[string]int    `env:"WEIGHTS" format:"json" default:"{\"a\": 1}"`
+		Policy  *Retry            `env:"POLICY" format:"json"`
+		Labels  map[string]string `env:"LABELS" format:"json"`
+	}
+
+	Convey("decodes values", t, func() {
+		defer resetEnv(os.Environ())
+
+		os.Setenv("RETRY", `{"attempts": 3, "backoff": "1s"}`)
+		os.Setenv("ROUTES", `[{"path": "/api", "backend": "api"}, {"path": "/", "backend": "web"}]`)
+		os.Setenv("POLICY", `{"attempts": 5}`)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Retry: Retry{Attempts: 3, Backoff: "1s"},
+			Routes: []Route{
+				{Path: "/api", Backend: "api"},
+				{Path: "/", Backend: "web"},
+			},
+			Weights: map[string]int{"a": 1},
+			Policy:  &Retry{Attempts: 5},
+		}
+		err := Parse(actual)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("syntax error", t, func() {
+		defer resetEnv(os.Environ())
+
+		os.Setenv("RETRY", `{"attempts": 3,}`)
+
+		err := Parse(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid JSON in variable [RETRY] at offset 16")
+	})
+
+	Convey("type error", t, func() {
+		defer resetEnv(os.Environ())
+
+		os.Setenv("RETRY", `{"attempts": "three"}`)
+
+		err := Parse(&TestStruct{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid JSON in variable [RETRY] at offset 20")
+		So(err.Error(), ShouldContainSubstring, "in field attempts")
+	})
+
+	Convey("bad default", t, func() {
+		type BadStruct struct {
+			Weights map[string]int `env:"WEIGHTS" format:"json" default:"{a: 1}"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", nil)))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid JSON in default value of [WEIGHTS] at offset 2")
+	})
+}
@@ -0,0 +1,45 @@
+package env
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// JSONFile reads a JSON config file at path and returns it as a Source.
+func JSONFile(path string) (Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return JSON(path, f)
+}
+
+// JSON reads a JSON object mapping variable names to values from r and returns
+// it as a Source named name. Strings are used as is, numbers and booleans as
+// they are written, and arrays and objects as JSON text for fields tagged
+// format:"json". Variables set to null are treated as unset.
+func JSON(name string, r io.Reader) (Source, error) {
+	raw := map[string]json.RawMessage{}
+	err := json.NewDecoder(r).Decode(&raw)
+	if err != nil {
+		return nil, jsonError(name, err)
+	}
+
+	values := map[string]string{}
+	for key, rawVal := range raw {
+		if string(rawVal) == "null" {
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(rawVal, &str); err == nil {
+			values[key] = str
+			continue
+		}
+		values[key] = string(rawVal)
+	}
+
+	return Map(name, values), nil
+}
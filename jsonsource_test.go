@@ -0,0 +1,87 @@
+package env
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSON(t *testing.T) {
+	Convey("parses values", t, func() {
+		contents := `{
+			"HOST": "db.internal",
+			"PORT": 5432,
+			"DEBUG": true,
+			"TAGS": ["a", "b"],
+			"LIMITS": {"cpu": 2},
+			"UNSET": null
+		}`
+
+		src, err := JSON("config.json", strings.NewReader(contents))
+		So(err, ShouldBeNil)
+		So(src.String(), ShouldEqual, "config.json")
+
+		tests := map[string]string{
+			"HOST":   "db.internal",
+			"PORT":   "5432",
+			"DEBUG":  "true",
+			"TAGS":   `["a", "b"]`,
+			"LIMITS": `{"cpu": 2}`,
+		}
+		for key, expected := range tests {
+			val, exists := src.Lookup(key)
+			So(exists, ShouldBeTrue)
+			So(val, ShouldEqual, expected)
+		}
+
+		_, exists := src.Lookup("UNSET")
+		So(exists, ShouldBeFalse)
+
+		type TestStruct struct {
+			Host   string         `env:"HOST"`
+			Port   int            `env:"PORT"`
+			Debug  bool           `env:"DEBUG"`
+			Limits map[string]int `env:"LIMITS" format:"json"`
+		}
+		actual := &TestStruct{}
+		err = Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, &TestStruct{
+			Host:   "db.internal",
+			Port:   5432,
+			Debug:  true,
+			Limits: map[string]int{"cpu": 2},
+		})
+	})
+
+	Convey("bad files", t, func() {
+		for _, contents := range []string{`{"HOST": `, `["HOST"]`} {
+			_, err := JSON("config.json", strings.NewReader(contents))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid JSON in config.json")
+		}
+	})
+
+	Convey("reads files", t, func() {
+		dir, err := ioutil.TempDir("", "env-json")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.json")
+		err = ioutil.WriteFile(path, []byte(`{"HOST": "fromfile"}`), 0600)
+		So(err, ShouldBeNil)
+
+		src, err := JSONFile(path)
+		So(err, ShouldBeNil)
+		val, exists := src.Lookup("HOST")
+		So(exists, ShouldBeTrue)
+		So(val, ShouldEqual, "fromfile")
+
+		_, err = JSONFile(filepath.Join(dir, "missing.json"))
+		So(err, ShouldNotBeNil)
+	})
+}
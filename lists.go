@@ -0,0 +1,138 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// listElements are the raw elements of a list variable.
+type listElements struct {
+	envName  string
+	raw      []string
+	failFast bool
+}
+
+// each calls parse with the index and raw value of each element. Errors are
+// prefixed with the element, as in PORTS[3]. All of the errors are returned
+// unless failFast is set, in which case parsing stops at the first one.
+func (l listElements) each(parse func(i int, raw string) error) error {
+	errs := []error{}
+	for i, raw := range l.raw {
+		err := parse(i, raw)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("%s[%d]: %w", l.envName, i, err)
+		if l.failFast {
+			return err
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return joinErrs(errs)
+	}
+	return nil
+}
+
+// splitList splits rawVal into the elements of a slice field according to its
+// delimiter and listFormat tags, trimming whitespace from elements if trim is set.
+func splitList(field reflect.StructField, rawVal string, trim bool) ([]string, error) {
+	if rawVal == "" {
+		return []string{}, nil
+	}
+
+	delim := getSliceDelim(field)
+
+	switch format := field.Tag.Get("listFormat"); format {
+	case "":
+		arr := strings.Split(rawVal, delim)
+		if trim {
+			for i, str := range arr {
+				arr[i] = strings.TrimSpace(str)
+			}
+		}
+		return arr, nil
+
+	case "csv":
+		if delim == "" {
+			return nil, fmt.Errorf("list format csv on %s requires a non-empty delimiter", field.Name)
+		}
+		return splitCSV(rawVal, delim, trim)
+
+	default:
+		return nil, fmt.Errorf("unsupported list format %q on %s", format, field.Name)
+	}
+}
+
+func getSliceDelim(field reflect.StructField) string {
+	delim, delimExists := field.Tag.Lookup("delimiter")
+	if !delimExists {
+		delim = ","
+	}
+
+	return delim
+}
+
+// splitCSV splits rawVal on delim, honoring CSV-style quoting: an element that
+// starts with a double quote runs until the matching closing quote and may
+// contain the delimiter, whitespace and doubled quotes ("") standing for a
+// literal quote. Quoted elements are never trimmed.
+func splitCSV(rawVal, delim string, trim bool) ([]string, error) {
+	arr := []string{}
+	rest := rawVal
+	for {
+		lead := strings.TrimLeft(rest, " \t")
+		if !strings.HasPrefix(lead, `"`) {
+			// Unquoted element
+			end := strings.Index(rest, delim)
+			if end < 0 {
+				end = len(rest)
+			}
+			elem := rest[:end]
+			if trim {
+				elem = strings.TrimSpace(elem)
+			}
+			arr = append(arr, elem)
+			if end == len(rest) {
+				return arr, nil
+			}
+			rest = rest[end+len(delim):]
+			continue
+		}
+
+		// Quoted element
+		var elem strings.Builder
+		i := 1
+		closed := false
+		for i < len(lead) {
+			if lead[i] != '"' {
+				elem.WriteByte(lead[i])
+				i++
+				continue
+			}
+			if i+1 < len(lead) && lead[i+1] == '"' {
+				elem.WriteByte('"')
+				i += 2
+				continue
+			}
+			closed = true
+			i++
+			break
+		}
+		if !closed {
+			return nil, fmt.Errorf("unterminated quoted element %d", len(arr))
+		}
+		arr = append(arr, elem.String())
+
+		rest = strings.TrimLeft(lead[i:], " \t")
+		if rest == "" {
+			return arr, nil
+		}
+		if !strings.HasPrefix(rest, delim) {
+			return nil, fmt.Errorf("unexpected characters after quoted element %d", len(arr)-1)
+		}
+		rest = rest[len(delim):]
+	}
+}
@@ -0,0 +1,203 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_lists(t *testing.T) {
+	Convey("csv list format", t, func() {
+		defer resetEnv(os.Environ())
+
+		type TestStruct struct {
+			Names []string `env:"NAMES" listFormat:"csv"`
+		}
+
+		tests := map[string][]string{
+			`"Smith, John",Jane`:     {"Smith, John", "Jane"},
+			` "Smith, John" , Jane `: {"Smith, John", "Jane"},
+			`"  padded  ",plain`:     {"  padded  ", "plain"},
+			`"say ""hi""",x`:         {`say "hi"`, "x"},
+			`a,,b`:                   {"a", "", "b"},
+			`a,""`:                   {"a", ""},
+			`5" tall,ok`:             {`5" tall`, "ok"},
+			`"only"`:                 {"only"},
+		}
+
+		for value, expectedArr := range tests {
+			actual := &TestStruct{}
+			expected := &TestStruct{
+				Names: expectedArr,
+			}
+			env := os.Environ()
+			os.Setenv("NAMES", value)
+			testParseEquality(actual, expected, true, env)
+		}
+	})
+
+	Convey("csv list format with custom delimiter", t, func() {
+		type TestStruct struct {
+			Names []string `env:"NAMES" listFormat:"csv" delimiter:"; "`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"NAMES": `"a; b"; c`,
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Names, ShouldResemble, []string{"a; b", "c"})
+	})
+
+	Convey("csv list format errors", t, func() {
+		type TestStruct struct {
+			Names []string `env:"NAMES" listFormat:"csv"`
+		}
+
+		tests := []string{
+			`"unterminated,a`,
+			`"quoted"junk,a`,
+		}
+		for _, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{
+				"NAMES": value,
+			})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("trim disabled", t, func() {
+		type TestStruct struct {
+			Plain []string `env:"PLAIN" trim:"false"`
+			CSV   []string `env:"CSV" trim:"false" listFormat:"csv"`
+		}
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Plain: []string{"a", " b ", " c"},
+			CSV:   []string{"a", " b ", "c"},
+		}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"PLAIN": "a, b , c",
+			"CSV":   `a, b ,"c"`,
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("bad tags", t, func() {
+		type BadTrim struct {
+			Names []string `env:"NAMES" trim:"nope"`
+		}
+		type BadFormat struct {
+			Names []string `env:"NAMES" listFormat:"tsv"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"NAMES": "a"}))
+
+		err := Parse(&BadTrim{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag trim")
+
+		err = Parse(&BadFormat{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported list format")
+	})
+}
+
+func TestParse_listErrors(t *testing.T) {
+	type TestStruct struct {
+		Ports    []uint16         `env:"PORTS" max:"9000"`
+		Rates    []float64        `env:"RATES" unit:"percent"`
+		Enabled  []bool           `env:"ENABLED"`
+		Hosts    []string         `env:"HOSTS" format:"hostport" failFast:"true"`
+		Timeouts [2]time.Duration `env:"TIMEOUTS"`
+	}
+
+	src := WithSource(Map("test", map[string]string{
+		"PORTS":    "80,http,443,65536",
+		"RATES":    "10%,150%",
+		"ENABLED":  "true,maybe",
+		"HOSTS":    "a:1,b,c",
+		"TIMEOUTS": "1s,forever",
+	}))
+
+	Convey("every bad element is reported with its index", t, func() {
+		err := Parse(&TestStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "PORTS[1]: ")
+		So(err.Error(), ShouldNotContainSubstring, "PORTS[2]")
+		So(err.Error(), ShouldContainSubstring, "PORTS[3]: ")
+		So(err.Error(), ShouldContainSubstring, "RATES[1]: Rates must be no more than")
+		So(err.Error(), ShouldContainSubstring, "ENABLED[1]: ")
+		So(err.Error(), ShouldContainSubstring, "TIMEOUTS[1]: ")
+	})
+
+	Convey("the failFast tag stops at the first bad element", t, func() {
+		err := Parse(&TestStruct{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "HOSTS[1]: ")
+		So(err.Error(), ShouldNotContainSubstring, "HOSTS[2]")
+	})
+
+	Convey("WithFailFast stops at the first error", t, func() {
+		err := Parse(&TestStruct{}, src, WithFailFast(true))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "PORTS[1]: ")
+		So(err.Error(), ShouldNotContainSubstring, "PORTS[3]")
+		So(err.Error(), ShouldNotContainSubstring, "RATES")
+
+		type Override struct {
+			Ports []uint16  `env:"PORTS" max:"9000" failFast:"false"`
+			Rates []float64 `env:"RATES" unit:"percent"`
+		}
+		err = Parse(&Override{}, src, WithFailFast(true))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "PORTS[3]: ")
+		So(err.Error(), ShouldNotContainSubstring, "RATES")
+	})
+
+	Convey("struct slices", t, func() {
+		type Upstream struct {
+			Host string `env:"HOST" required:"true"`
+			Port int    `env:"PORT"`
+		}
+		type UpstreamStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+		upstreams := WithSource(Map("test", map[string]string{
+			"UPSTREAM_0_PORT": "http",
+			"UPSTREAM_1_PORT": "https",
+		}))
+
+		err := Parse(&UpstreamStruct{}, upstreams)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "UPSTREAM_0_HOST")
+		So(err.Error(), ShouldContainSubstring, "UPSTREAM_1_HOST")
+
+		err = Parse(&UpstreamStruct{}, upstreams, WithFailFast(true))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [UPSTREAM_0_HOST]")
+		So(err.Error(), ShouldNotContainSubstring, "UPSTREAM_0_PORT")
+		So(err.Error(), ShouldNotContainSubstring, "UPSTREAM_1")
+
+		type FailFastStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM" failFast:"true"`
+		}
+		err = Parse(&FailFastStruct{}, upstreams)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [UPSTREAM_0_HOST]")
+		So(err.Error(), ShouldContainSubstring, "UPSTREAM_0_PORT")
+		So(err.Error(), ShouldNotContainSubstring, "UPSTREAM_1")
+
+		type OverrideStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM" failFast:"false"`
+		}
+		err = Parse(&OverrideStruct{}, upstreams, WithFailFast(true))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "UPSTREAM_0_HOST")
+		So(err.Error(), ShouldContainSubstring, "UPSTREAM_1_HOST")
+	})
+}
@@ -0,0 +1,116 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LogConfig is the standard logging configuration of a service. Parse it with
+// env.Parse like any other struct and build a handler with Handler:
+//
+//	conf := env.LogConfig{}
+//	err := env.Parse(&conf)
+//	...
+//	logger := slog.New(conf.Handler(os.Stderr))
+type LogConfig struct {
+	// Level is the minimum level that is logged, such as DEBUG, WARN or INFO+2
+	Level slog.Level `env:"LOG_LEVEL" default:"INFO"`
+
+	// Format is either text or json
+	Format LogFormat `env:"LOG_FORMAT" default:"text"`
+
+	// AddSource adds the file and line of the logging call to every record
+	AddSource bool `env:"LOG_ADD_SOURCE" default:"false"`
+}
+
+// LogFormat is the output format of the handler built by LogConfig. It is
+// parsed case-insensitively, so LOG_FORMAT=JSON works too.
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// Handler returns a slog.Handler that writes to w in the configured format.
+func (c LogConfig) Handler(w io.Writer) slog.Handler {
+	opts := &slog.HandlerOptions{
+		Level:     c.Level,
+		AddSource: c.AddSource,
+	}
+	if strings.EqualFold(string(c.Format), string(LogFormatJSON)) {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+func handleLogFormat(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	format := LogFormat(strings.ToLower(rawVal))
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("%s must be either %s or %s", field.Name, LogFormatText, LogFormatJSON)
+	}
+	value.SetString(string(format))
+	return nil
+}
+
+func handleLevel(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	level, err := parseLevel(rawVal)
+	if err != nil {
+		return err
+	}
+
+	min, err := getLevelTag(field, "min")
+	if err != nil {
+		return err
+	}
+	max, err := getLevelTag(field, "max")
+	if err != nil {
+		return err
+	}
+
+	if min != nil && level < *min {
+		return fmt.Errorf("%s must be at least %s", field.Name, *min)
+	}
+	if max != nil && level > *max {
+		return fmt.Errorf("%s must be no more than %s", field.Name, *max)
+	}
+
+	value.SetInt(int64(level))
+	return nil
+}
+
+// parseLevel parses a level name with an optional offset, such as WARN or
+// info+2, or a plain integer such as -4.
+func parseLevel(rawVal string) (slog.Level, error) {
+	if i, err := strconv.ParseInt(rawVal, 10, 0); err == nil {
+		return slog.Level(i), nil
+	}
+	var level slog.Level
+	err := level.UnmarshalText([]byte(rawVal))
+	if err != nil {
+		return 0, fmt.Errorf("invalid log level %q: must be DEBUG, INFO, WARN or ERROR with an optional offset, or an integer", rawVal)
+	}
+	return level, nil
+}
+
+func getLevelTag(field reflect.StructField, tag string) (*slog.Level, error) {
+	rawVal, exists := field.Tag.Lookup(tag)
+	if !exists {
+		return nil, nil
+	}
+	level, err := parseLevel(rawVal)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tag %s on %s: %s", tag, field.Name, err)
+	}
+	return &level, nil
+}
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_logLevel(t *testing.T) {
+	Convey("log levels", t, func() {
+		type TestStruct struct {
+			Level slog.Level `env:"LEVEL"`
+		}
+
+		tests := map[string]slog.Level{
+			"DEBUG":   slog.LevelDebug,
+			"info":    slog.LevelInfo,
+			"Warn":    slog.LevelWarn,
+			"ERROR":   slog.LevelError,
+			"INFO+2":  slog.LevelInfo + 2,
+			"error-1": slog.LevelError - 1,
+			"-4":      slog.LevelDebug,
+			"12":      slog.Level(12),
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"LEVEL": value})))
+			So(err, ShouldBeNil)
+			So(actual.Level, ShouldEqual, expected)
+		}
+
+		for _, value := range []string{"verbose", "INFO+", "2.5", "WARNING"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"LEVEL": value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid log level")
+		}
+	})
+
+	Convey("min/max levels", t, func() {
+		type TestStruct struct {
+			Level slog.Level `env:"LEVEL" min:"DEBUG" max:"WARN"`
+		}
+
+		tests := map[string]bool{
+			"DEBUG-1": false,
+			"DEBUG":   true,
+			"INFO+2":  true,
+			"WARN":    true,
+			"ERROR":   false,
+		}
+
+		for value, pass := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"LEVEL": value})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"LEVEL": "ERROR"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Level must be no more than WARN")
+	})
+}
+
+func TestLogConfig(t *testing.T) {
+	Convey("defaults", t, func() {
+		conf := LogConfig{}
+		err := Parse(&conf, WithSource(Map("test", map[string]string{})))
+		So(err, ShouldBeNil)
+		So(conf, ShouldResemble, LogConfig{Level: slog.LevelInfo, Format: "text"})
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(conf.Handler(buf))
+		logger.Debug("hidden")
+		logger.Info("shown", "key", "value")
+		So(buf.String(), ShouldNotContainSubstring, "hidden")
+		So(buf.String(), ShouldContainSubstring, "msg=shown key=value")
+	})
+
+	Convey("json with source", t, func() {
+		conf := LogConfig{}
+		err := Parse(&conf, WithSource(Map("test", map[string]string{
+			"LOG_LEVEL":      "WARN",
+			"LOG_FORMAT":     "json",
+			"LOG_ADD_SOURCE": "true",
+		})))
+		So(err, ShouldBeNil)
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(conf.Handler(buf))
+		logger.Info("hidden")
+		logger.Warn("shown")
+		So(strings.Count(buf.String(), "\n"), ShouldEqual, 1)
+		So(buf.String(), ShouldContainSubstring, `"msg":"shown"`)
+		So(buf.String(), ShouldContainSubstring, `"source":`)
+	})
+
+	Convey("invalid format", t, func() {
+		conf := LogConfig{}
+		err := Parse(&conf, WithSource(Map("test", map[string]string{"LOG_FORMAT": "xml"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Format must be either text or json")
+	})
+
+	Convey("format is case-insensitive", t, func() {
+		conf := LogConfig{}
+		err := Parse(&conf, WithSource(Map("test", map[string]string{"LOG_FORMAT": "JSON"})))
+		So(err, ShouldBeNil)
+		So(conf.Format, ShouldEqual, LogFormatJSON)
+
+		buf := &bytes.Buffer{}
+		slog.New(LogConfig{Format: "Json"}.Handler(buf)).Info("shown")
+		So(buf.String(), ShouldContainSubstring, `"msg":"shown"`)
+	})
+
+	Convey("passes Check", t, func() {
+		So(Check(&LogConfig{}), ShouldBeNil)
+	})
+}
@@ -0,0 +1,149 @@
+package env
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+)
+
+// handleNetValue parses a single net.IP, *net.IPNet, netip.Addr, netip.Prefix
+// or netip.AddrPort field.
+func handleNetValue(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	val, err := parseNetValue(field, value.Type(), rawVal)
+	if err != nil {
+		return err
+	}
+	value.Set(val)
+	return nil
+}
+
+func handleNetSlice(value reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
+		return nil
+	}
+	arr := reflect.MakeSlice(value.Type(), len(elems.raw), len(elems.raw))
+
+	err := elems.each(func(i int, str string) error {
+		val, err := parseNetValue(field, value.Type().Elem(), str)
+		if err != nil {
+			return err
+		}
+		arr.Index(i).Set(val)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	value.Set(arr)
+	return nil
+}
+
+// parseNetValue parses rawVal into a value of type t and checks it against the
+// family tag of the field.
+func parseNetValue(field reflect.StructField, t reflect.Type, rawVal string) (reflect.Value, error) {
+	var addr netip.Addr
+	var val interface{}
+
+	switch t {
+	case ipType:
+		ip := net.ParseIP(rawVal)
+		if ip == nil {
+			return reflect.Value{}, fmt.Errorf("invalid IP address %q", rawVal)
+		}
+		addr, _ = netip.AddrFromSlice(ip)
+		val = ip
+
+	case reflect.PtrTo(ipNetType):
+		_, ipNet, err := net.ParseCIDR(rawVal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		addr, _ = netip.AddrFromSlice(ipNet.IP)
+		val = ipNet
+
+	case addrType:
+		a, err := netip.ParseAddr(rawVal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		addr = a
+		val = a
+
+	case prefixType:
+		prefix, err := netip.ParsePrefix(rawVal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		addr = prefix.Addr()
+		val = prefix
+
+	case addrPortType:
+		addrPort, err := netip.ParseAddrPort(rawVal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		addr = addrPort.Addr()
+		val = addrPort
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported network type %s", t)
+	}
+
+	err := checkFamily(field, addr)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(val), nil
+}
+
+// checkFamily enforces the family tag, which may be "ipv4" or "ipv6". IPv4
+// addresses mapped into IPv6 (::ffff:1.2.3.4) count as IPv4.
+func checkFamily(field reflect.StructField, addr netip.Addr) error {
+	family, exists := field.Tag.Lookup("family")
+	if !exists || family == "" {
+		return nil
+	}
+
+	is4 := addr.Unmap().Is4()
+	switch family {
+	case "ipv4":
+		if !is4 {
+			return fmt.Errorf("%s must be an IPv4 address", field.Name)
+		}
+	case "ipv6":
+		if is4 {
+			return fmt.Errorf("%s must be an IPv6 address", field.Name)
+		}
+	default:
+		return fmt.Errorf("unsupported family %q on %s", family, field.Name)
+	}
+	return nil
+}
+
+// checkHostPort validates that rawVal is a host:port pair with a numeric port.
+// The host may be empty (":8080") to mean all interfaces, and if it is an IP
+// address it is checked against the family tag.
+func checkHostPort(field reflect.StructField, rawVal string) error {
+	host, rawPort, err := net.SplitHostPort(rawVal)
+	if err != nil {
+		return err
+	}
+
+	_, err = strconv.ParseUint(rawPort, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q in %q", rawPort, rawVal)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		// Not an IP address, so it's a hostname and there's no family to check
+		return nil
+	}
+	return checkFamily(field, addr)
+}
@@ -0,0 +1,132 @@
+package env
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_netaddrs(t *testing.T) {
+	type TestStruct struct {
+		IP       net.IP         `env:"IP"`
+		Network  *net.IPNet     `env:"NETWORK"`
+		Addr     netip.Addr     `env:"ADDR"`
+		Prefix   netip.Prefix   `env:"PREFIX"`
+		AddrPort netip.AddrPort `env:"ADDRPORT"`
+		Listen   string         `env:"LISTEN" format:"hostport"`
+
+		IPs       []net.IP         `env:"IPS"`
+		Networks  []*net.IPNet     `env:"NETWORKS"`
+		Addrs     []netip.Addr     `env:"ADDRS"`
+		Prefixes  []netip.Prefix   `env:"PREFIXES"`
+		AddrPorts []netip.AddrPort `env:"ADDRPORTS"`
+		Peers     []string         `env:"PEERS" format:"hostport"`
+	}
+
+	Convey("network values", t, func() {
+		_, network, err := net.ParseCIDR("10.0.0.0/8")
+		So(err, ShouldBeNil)
+		_, network6, err := net.ParseCIDR("fd00::/8")
+		So(err, ShouldBeNil)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			IP:       net.ParseIP("192.168.1.1"),
+			Network:  network,
+			Addr:     netip.MustParseAddr("::1"),
+			Prefix:   netip.MustParsePrefix("192.168.0.0/16"),
+			AddrPort: netip.MustParseAddrPort("[::1]:8080"),
+			Listen:   ":8080",
+
+			IPs:       []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")},
+			Networks:  []*net.IPNet{network, network6},
+			Addrs:     []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("fe80::1")},
+			Prefixes:  []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::/0")},
+			AddrPorts: []netip.AddrPort{netip.MustParseAddrPort("127.0.0.1:80"), netip.MustParseAddrPort("[::1]:443")},
+			Peers:     []string{"db.example.com:5432", "10.0.0.2:5432"},
+		}
+
+		err = Parse(actual, WithSource(Map("test", map[string]string{
+			"IP":       "192.168.1.1",
+			"NETWORK":  "10.0.0.0/8",
+			"ADDR":     "::1",
+			"PREFIX":   "192.168.0.0/16",
+			"ADDRPORT": "[::1]:8080",
+			"LISTEN":   ":8080",
+
+			"IPS":       "10.0.0.1, ::1",
+			"NETWORKS":  "10.0.0.0/8, fd00::/8",
+			"ADDRS":     "10.0.0.1, fe80::1",
+			"PREFIXES":  "10.0.0.0/8, ::/0",
+			"ADDRPORTS": "127.0.0.1:80, [::1]:443",
+			"PEERS":     "db.example.com:5432, 10.0.0.2:5432",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("invalid values", t, func() {
+		tests := map[string]string{
+			"IP":        "192.168.1",
+			"NETWORK":   "10.0.0.0",
+			"ADDR":      "localhost",
+			"PREFIX":    "10.0.0.0/33",
+			"ADDRPORT":  "127.0.0.1",
+			"LISTEN":    "localhost",
+			"IPS":       "10.0.0.1, nope",
+			"NETWORKS":  "10.0.0.0/8, nope",
+			"ADDRS":     "nope",
+			"PREFIXES":  "nope",
+			"ADDRPORTS": "nope",
+			"PEERS":     "db.example.com:http",
+		}
+
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("family", t, func() {
+		type FamilyStruct struct {
+			IP       net.IP         `env:"IP" family:"ipv4"`
+			Addrs    []netip.Addr   `env:"ADDRS" family:"ipv6"`
+			Prefix   netip.Prefix   `env:"PREFIX" family:"ipv4"`
+			AddrPort netip.AddrPort `env:"ADDRPORT" family:"ipv6"`
+			Network  *net.IPNet     `env:"NETWORK" family:"ipv6"`
+			Listen   string         `env:"LISTEN" format:"hostport" family:"ipv4"`
+		}
+
+		tests := map[string]map[string]bool{
+			"IP":       {"10.0.0.1": true, "::ffff:10.0.0.1": true, "::1": false},
+			"ADDRS":    {"::1, fe80::1": true, "::1, 10.0.0.1": false},
+			"PREFIX":   {"10.0.0.0/8": true, "fd00::/8": false},
+			"ADDRPORT": {"[::1]:80": true, "10.0.0.1:80": false},
+			"NETWORK":  {"fd00::/8": true, "10.0.0.0/8": false},
+			"LISTEN":   {"10.0.0.1:80": true, "example.com:80": true, ":80": true, "[::1]:80": false},
+		}
+
+		for name, values := range tests {
+			for value, pass := range values {
+				err := Parse(&FamilyStruct{}, WithSource(Map("test", map[string]string{name: value})))
+				if pass {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("bad family tag", t, func() {
+		type BadStruct struct {
+			IP net.IP `env:"IP" family:"ipv5"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"IP": "10.0.0.1"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported family")
+	})
+}
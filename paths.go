@@ -0,0 +1,55 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// parsePath expands and validates a string field with format:"path" according
+// to its expandHome, absolute, mustExist, isDir and isFile tags.
+func parsePath(field reflect.StructField, rawVal string) (string, error) {
+	tags := map[string]bool{}
+	for _, tag := range []string{"expandHome", "absolute", "mustExist", "isDir", "isFile"} {
+		val, err := getBoolTag(field, tag, false)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse tag %s on %s: %s", tag, field.Name, err)
+		}
+		tags[tag] = val
+	}
+
+	path := rawVal
+	if tags["expandHome"] && (path == "~" || strings.HasPrefix(path, "~/")) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to expand %s: %s", field.Name, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	if tags["absolute"] && !filepath.IsAbs(path) {
+		return "", fmt.Errorf("%s must be an absolute path", field.Name)
+	}
+
+	if !tags["mustExist"] && !tags["isDir"] && !tags["isFile"] {
+		return path, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s does not exist: %s", field.Name, path)
+		}
+		return "", err
+	}
+	if tags["isDir"] && !info.IsDir() {
+		return "", fmt.Errorf("%s must be a directory: %s", field.Name, path)
+	}
+	if tags["isFile"] && !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s must be a regular file: %s", field.Name, path)
+	}
+
+	return path, nil
+}
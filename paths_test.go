@@ -0,0 +1,91 @@
+package env
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_paths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "config.yaml")
+	err = ioutil.WriteFile(file, []byte("{}"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	Convey("validation", t, func() {
+		type TestStruct struct {
+			Plain    string   `env:"PLAIN" format:"path"`
+			Absolute string   `env:"ABSOLUTE" format:"path" absolute:"true"`
+			Exists   string   `env:"EXISTS" format:"path" mustExist:"true"`
+			Dir      string   `env:"DIR" format:"path" isDir:"true"`
+			File     string   `env:"FILE" format:"path" isFile:"true"`
+			Files    []string `env:"FILES" format:"path" isFile:"true"`
+		}
+
+		tests := map[string]map[string]bool{
+			"PLAIN":    {"relative/path": true, missing: true},
+			"ABSOLUTE": {dir: true, "relative/path": false},
+			"EXISTS":   {dir: true, file: true, missing: false},
+			"DIR":      {dir: true, file: false, missing: false},
+			"FILE":     {file: true, dir: false, missing: false},
+			"FILES":    {file + "," + file: true, file + "," + dir: false},
+		}
+
+		for name, values := range tests {
+			for value, pass := range values {
+				err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+				if pass {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("expand home", t, func() {
+		type TestStruct struct {
+			Expanded   string `env:"EXPANDED" format:"path" expandHome:"true" absolute:"true"`
+			Home       string `env:"HOME_DIR" format:"path" expandHome:"true"`
+			Unexpanded string `env:"UNEXPANDED" format:"path"`
+		}
+
+		home, err := os.UserHomeDir()
+		So(err, ShouldBeNil)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Expanded:   filepath.Join(home, ".config/app"),
+			Home:       home,
+			Unexpanded: "~/.config/app",
+		}
+		err = Parse(actual, WithSource(Map("test", map[string]string{
+			"EXPANDED":   "~/.config/app",
+			"HOME_DIR":   "~",
+			"UNEXPANDED": "~/.config/app",
+		})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("bad tag", t, func() {
+		type BadStruct struct {
+			Path string `env:"PATH_VAR" format:"path" isDir:"dunno"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"PATH_VAR": dir})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag isDir")
+	})
+}
@@ -0,0 +1,34 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"text/template"
+)
+
+func handleRegexp(value reflect.Value, envName, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	re, err := regexp.Compile(rawVal)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression in variable [%s]: %s", envName, err)
+	}
+	value.Set(reflect.ValueOf(re))
+	return nil
+}
+
+// handleTemplate parses a text/template named after the variable, so parse and
+// execution errors name the variable too.
+func handleTemplate(value reflect.Value, envName, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	tmpl, err := template.New(envName).Parse(rawVal)
+	if err != nil {
+		return fmt.Errorf("invalid template in variable [%s]: %s", envName, err)
+	}
+	value.Set(reflect.ValueOf(tmpl))
+	return nil
+}
@@ -0,0 +1,52 @@
+package env
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_patterns(t *testing.T) {
+	type TestStruct struct {
+		Pattern  *regexp.Regexp     `env:"PATTERN"`
+		Template *template.Template `env:"TEMPLATE"`
+	}
+
+	Convey("regexp and template", t, func() {
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"PATTERN":  `^api-\d+$`,
+			"TEMPLATE": "Hello, {{.}}!",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Pattern.MatchString("api-42"), ShouldBeTrue)
+		So(actual.Pattern.MatchString("web-42"), ShouldBeFalse)
+
+		buf := &bytes.Buffer{}
+		err = actual.Template.Execute(buf, "world")
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, "Hello, world!")
+		So(actual.Template.Name(), ShouldEqual, "TEMPLATE")
+	})
+
+	Convey("unset", t, func() {
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", nil)))
+		So(err, ShouldBeNil)
+		So(actual.Pattern, ShouldBeNil)
+		So(actual.Template, ShouldBeNil)
+	})
+
+	Convey("errors name the variable", t, func() {
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"PATTERN": "api-(\\d+"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid regular expression in variable [PATTERN]")
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"TEMPLATE": "{{.Name"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid template in variable [TEMPLATE]")
+	})
+}
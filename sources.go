@@ -0,0 +1,202 @@
+package env
+
+import (
+	"flag"
+	"os"
+	"strings"
+)
+
+// Source provides the raw values of variables to Parse.
+type Source interface {
+	// Lookup returns the value of the named variable and whether it was set.
+	Lookup(name string) (string, bool)
+
+	// Keys lists the names of every variable the source has set.
+	Keys() []string
+
+	// String names the source in provenance reports.
+	String() string
+}
+
+// OS returns a Source backed by the process environment.
+func OS() Source {
+	return osSource{}
+}
+
+type osSource struct{}
+
+func (osSource) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+func (osSource) Keys() []string {
+	environ := os.Environ()
+	keys := make([]string, 0, len(environ))
+	for _, keyVal := range environ {
+		keys = append(keys, strings.SplitN(keyVal, "=", 2)[0])
+	}
+	return keys
+}
+
+func (osSource) String() string {
+	return "env"
+}
+
+// Map returns a Source backed by a map, such as defaults baked into the program.
+func Map(name string, values map[string]string) Source {
+	return mapSource{
+		name:   name,
+		values: values,
+	}
+}
+
+type mapSource struct {
+	name   string
+	values map[string]string
+}
+
+func (m mapSource) Lookup(name string) (string, bool) {
+	val, exists := m.values[name]
+	return val, exists
+}
+
+func (m mapSource) Keys() []string {
+	keys := make([]string, 0, len(m.values))
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (m mapSource) String() string {
+	return m.name
+}
+
+// Flags returns a Source backed by the flags that were explicitly set on fs. A
+// variable is matched against a flag of the same name, or failing that, against
+// its lower-case form with underscores replaced by dashes (DB_HOST -> db-host).
+// The flag set must already be parsed.
+func Flags(fs *flag.FlagSet) Source {
+	return flagSource{
+		fs: fs,
+	}
+}
+
+type flagSource struct {
+	fs *flag.FlagSet
+}
+
+func (f flagSource) Lookup(name string) (string, bool) {
+	set := f.setFlags()
+	fl, exists := set[name]
+	if !exists {
+		fl, exists = set[flagName(name)]
+	}
+	if !exists {
+		return "", false
+	}
+	return fl.Value.String(), true
+}
+
+// Keys returns the variable names that Lookup accepts for the set flags, so the
+// flag db-host is listed as DB_HOST.
+func (f flagSource) Keys() []string {
+	set := f.setFlags()
+	keys := make([]string, 0, len(set))
+	for name := range set {
+		if envName := strings.ToUpper(strings.Replace(name, "-", "_", -1)); flagName(envName) == name {
+			name = envName
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+func (f flagSource) setFlags() map[string]*flag.Flag {
+	set := map[string]*flag.Flag{}
+	f.fs.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = fl
+	})
+	return set
+}
+
+func (f flagSource) String() string {
+	return "flags"
+}
+
+func flagName(envName string) string {
+	return strings.Replace(strings.ToLower(envName), "_", "-", -1)
+}
+
+// Chain returns a Source that resolves each variable through srcs in order. The
+// first source that has the variable set to a non-empty value wins, so sources
+// should be listed from highest to lowest precedence. A variable that is only
+// ever set to "" resolves to the first of those empty values.
+func Chain(srcs ...Source) Source {
+	return chain(srcs)
+}
+
+type chain []Source
+
+func (c chain) Lookup(name string) (string, bool) {
+	val, _, exists := lookup(c, name, false, false)
+	return val, exists
+}
+
+func (c chain) Keys() []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, src := range c {
+		for _, key := range src.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+func (c chain) String() string {
+	names := make([]string, len(c))
+	for i, src := range c {
+		names[i] = src.String()
+	}
+	return "chain(" + strings.Join(names, ", ") + ")"
+}
+
+// lookup resolves name in src and also returns the name of the source that
+// supplied the value. Chains are descended so the innermost source is reported.
+// If trim is set the value is trimmed of whitespace. A set but empty value only
+// stops the search if allowEmpty is set; otherwise later sources get a chance to
+// supply a value first.
+func lookup(src Source, name string, trim, allowEmpty bool) (string, string, bool) {
+	c, isChain := src.(chain)
+	if !isChain {
+		val, exists := src.Lookup(name)
+		if !exists {
+			return "", "", false
+		}
+		if trim {
+			val = strings.TrimSpace(val)
+		}
+		return val, src.String(), true
+	}
+
+	foundEmpty := false
+	emptyOrigin := ""
+	for _, s := range c {
+		val, origin, exists := lookup(s, name, trim, allowEmpty)
+		if !exists {
+			continue
+		}
+		if val != "" || allowEmpty {
+			return val, origin, true
+		}
+		if !foundEmpty {
+			foundEmpty = true
+			emptyOrigin = origin
+		}
+	}
+	return "", emptyOrigin, foundEmpty
+}
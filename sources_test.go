@@ -0,0 +1,162 @@
+package env
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_sources(t *testing.T) {
+	type TestStruct struct {
+		Host    string `env:"DB_HOST"`
+		Port    int    `env:"DB_PORT"`
+		User    string `env:"DB_USER"`
+		Timeout string `env:"DB_TIMEOUT" default:"5s"`
+		Unset   string `env:"DB_UNSET"`
+	}
+
+	Convey("map source", t, func() {
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Host:    "localhost",
+			Timeout: "5s",
+		}
+
+		src := Map("defaults", map[string]string{"DB_HOST": "localhost"})
+		err := Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("chain precedence and provenance", t, func() {
+		defer resetEnv(os.Environ())
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.String("db-host", "flaghost", "")
+		fs.String("DB_USER", "flaguser", "")
+		err := fs.Parse([]string{"-db-host", "fromflag"})
+		So(err, ShouldBeNil)
+
+		os.Setenv("DB_HOST", "fromenv")
+		os.Setenv("DB_PORT", "5432")
+
+		src := Chain(
+			Flags(fs),
+			OS(),
+			Map("defaults", map[string]string{
+				"DB_PORT": "1234",
+				"DB_USER": "admin",
+			}),
+		)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Host:    "fromflag",
+			Port:    5432,
+			User:    "admin",
+			Timeout: "5s",
+		}
+		prov := Provenance{}
+
+		err = Parse(actual, WithSource(src), WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+		So(prov, ShouldResemble, Provenance{
+			"Host":    "flags",
+			"Port":    "env",
+			"User":    "defaults",
+			"Timeout": "default",
+		})
+	})
+
+	Convey("nested chain reports innermost source", t, func() {
+		src := Chain(
+			Map("first", map[string]string{}),
+			Chain(Map("second", map[string]string{"DB_HOST": "h"})),
+		)
+
+		val, exists := src.Lookup("DB_HOST")
+		So(exists, ShouldBeTrue)
+		So(val, ShouldEqual, "h")
+
+		prov := Provenance{}
+		err := Parse(&TestStruct{}, WithSource(src), WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(prov["Host"], ShouldEqual, "second")
+	})
+
+	Convey("empty values fall through to later sources", t, func() {
+		type EmptyStruct struct {
+			Host  string `env:"DB_HOST"`
+			User  string `env:"DB_USER" allowEmpty:"true"`
+			Pass  string `env:"DB_PASS"`
+			Token string `env:"TOKEN" notEmpty:"true"`
+		}
+
+		src := Chain(
+			Map("env", map[string]string{"DB_HOST": "", "DB_USER": "", "DB_PASS": "  ", "TOKEN": ""}),
+			Map("dotenv", map[string]string{"DB_HOST": "fromdotenv", "DB_USER": "admin", "DB_PASS": "s3cret"}),
+		)
+
+		actual := &EmptyStruct{}
+		prov := Provenance{}
+		err := Parse(actual, WithSource(src), WithProvenance(prov))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "variable [TOKEN] is set but empty")
+		So(actual.Host, ShouldEqual, "fromdotenv")
+		So(actual.User, ShouldEqual, "")
+		So(actual.Pass, ShouldEqual, "s3cret")
+		So(prov["Host"], ShouldEqual, "dotenv")
+		So(prov["User"], ShouldEqual, "env")
+
+		val, exists := src.Lookup("DB_HOST")
+		So(exists, ShouldBeTrue)
+		So(val, ShouldEqual, "fromdotenv")
+		val, exists = src.Lookup("TOKEN")
+		So(exists, ShouldBeTrue)
+		So(val, ShouldEqual, "")
+	})
+
+	Convey("struct slices from flags", t, func() {
+		type Upstream struct {
+			Host string `env:"HOST"`
+			Port int    `env:"PORT"`
+		}
+		type UpstreamStruct struct {
+			Upstreams []Upstream `env:"UP"`
+		}
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.String("up-0-host", "", "")
+		fs.String("up-1-host", "", "")
+		fs.String("UP_1_PORT", "", "")
+		fs.String("Other-Flag", "", "")
+		err := fs.Parse([]string{"-up-0-host=a", "-up-1-host=b", "-UP_1_PORT=8080", "-Other-Flag=x"})
+		So(err, ShouldBeNil)
+
+		src := Flags(fs)
+		So(src.Keys(), ShouldHaveLength, 4)
+		for _, key := range src.Keys() {
+			_, exists := src.Lookup(key)
+			So(exists, ShouldBeTrue)
+		}
+
+		actual := &UpstreamStruct{}
+		err = Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual.Upstreams, ShouldResemble, []Upstream{{Host: "a"}, {Host: "b", Port: 8080}})
+	})
+
+	Convey("required variable missing from every source", t, func() {
+		type RequiredStruct struct {
+			Host string `env:"DB_HOST" required:"true"`
+		}
+
+		src := Chain(Map("a", nil), Map("b", map[string]string{"OTHER": "x"}))
+		err := Parse(&RequiredStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [DB_HOST]")
+	})
+}
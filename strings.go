@@ -1,16 +1,75 @@
 package env
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
-func handleString(value reflect.Value, rawVal string) error {
+func handleString(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal != "" {
+		var err error
+		rawVal, err = formatString(field, rawVal)
+		if err != nil {
+			return err
+		}
+	}
 	value.SetString(rawVal)
 	return nil
 }
 
-func handleStringSlice(value reflect.Value, rawArr []string) error {
-	if len(rawArr) == 0 {
+func handleStringSlice(value reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
 		return nil
 	}
-	value.Set(reflect.ValueOf(rawArr))
+	arr := make([]string, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, rawVal string) error {
+		val, err := formatString(field, rawVal)
+		arr[i] = val
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	value.Set(reflect.ValueOf(arr))
 	return nil
 }
+
+// formatString validates a string value against the oneof and format tags of
+// the field, returning the value to store.
+func formatString(field reflect.StructField, rawVal string) (string, error) {
+	err := checkOneOf(field, rawVal)
+	if err != nil {
+		return "", err
+	}
+
+	switch format := field.Tag.Get("format"); format {
+	case "":
+		return rawVal, nil
+	case "hostport":
+		return rawVal, checkHostPort(field, rawVal)
+	case "path":
+		return parsePath(field, rawVal)
+	case "decimal":
+		return parseDecimal(field, rawVal)
+	default:
+		return "", fmt.Errorf("unsupported format %q on %s", format, field.Name)
+	}
+}
+
+// checkOneOf checks that a string value is one of the comma-separated choices in
+// the oneof tag. Choices are matched exactly.
+func checkOneOf(field reflect.StructField, rawVal string) error {
+	rawChoices, exists := field.Tag.Lookup("oneof")
+	if !exists {
+		return nil
+	}
+	choices := strings.Split(rawChoices, ",")
+	for i, choice := range choices {
+		choices[i] = strings.TrimSpace(choice)
+		if choices[i] == rawVal {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of [%s]", field.Name, strings.Join(choices, ", "))
+}
@@ -37,3 +37,35 @@ func TestParse_string(t *testing.T) {
 		So(actual, ShouldResemble, expected)
 	})
 }
+
+func TestParse_oneof(t *testing.T) {
+	Convey("oneof", t, func() {
+		type TestStruct struct {
+			Mode  string   `env:"MODE" oneof:"dev, staging, prod"`
+			Modes []string `env:"MODES" oneof:"read,write"`
+		}
+
+		tests := map[string]bool{
+			"MODE=dev":          true,
+			"MODE=prod":         true,
+			"MODE=Prod":         false,
+			"MODE=test":         false,
+			"MODES=read,write":  true,
+			"MODES=read,delete": false,
+		}
+
+		for keyVal, pass := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"MODE": "test"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Mode must be one of [dev, staging, prod]")
+	})
+}
@@ -0,0 +1,181 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// isStructSlice reports whether t is a []Struct or []*Struct whose elements are
+// parsed field by field rather than from a single value like url.URL.
+func isStructSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct && !valueStructs[elem]
+}
+
+// isEmbeddedStruct reports whether field is an embedded struct or pointer to a
+// struct whose fields are flattened into the parent.
+func isEmbeddedStruct(field reflect.StructField) bool {
+	if !field.Anonymous {
+		return false
+	}
+	t := structType(field.Type)
+	return t != nil && !valueStructs[t]
+}
+
+// handleEmbedded parses the fields of an embedded struct as though they were
+// declared in the parent. An env tag on the embedded field adds a prefix, so
+// env:"DB" reads its Host field tagged env:"HOST" from DB_HOST. Nil pointers
+// are allocated, unless the struct embeds itself.
+func (p *parser) handleEmbedded(value reflect.Value, field reflect.StructField, prefix, envName, path string) error {
+	if envName != "" {
+		prefix += envName + "_"
+	}
+	if t := structType(field.Type); p.parsing[t] > 0 {
+		err := fmt.Errorf("unable to parse %s: %s embeds itself", path, t)
+		return newFieldError(field, path, "", "", err)
+	}
+
+	if field.Type.Kind() == reflect.Ptr {
+		// The fields of a pointer to an unexported type can't be set even if it
+		// isn't nil, whereas an unexported struct can still have exported fields set
+		if !value.CanSet() {
+			err := fmt.Errorf("unable to set fields of %s: embedded pointers must be to exported types", path)
+			return newFieldError(field, path, "", "", err)
+		}
+		if value.IsNil() {
+			value.Set(reflect.New(field.Type.Elem()))
+		}
+		value = value.Elem()
+	}
+
+	return p.parseStruct(value, prefix, path)
+}
+
+// handleStructSlice populates a slice of structs from indexed variables. For a
+// field tagged env:"UPSTREAM", element i is parsed with the prefix UPSTREAM_i_,
+// so its Host field tagged env:"HOST" is read from UPSTREAM_0_HOST.
+func (p *parser) handleStructSlice(value reflect.Value, field reflect.StructField, envName, path string, required bool) error {
+	indices, err := structIndices(p.source.Keys(), envName)
+	if err != nil {
+		return newFieldError(field, path, envName+"_*", "", err)
+	}
+	if len(indices) == 0 {
+		if required {
+			err = fmt.Errorf("missing required variable [%s_0_*]", envName)
+			return newFieldError(field, path, envName+"_*", "", err)
+		}
+		return nil
+	}
+	failFast, err := getBoolTag(field, "failFast", p.failFast)
+	if err != nil {
+		err = fmt.Errorf("unable to parse tag failFast on %s: %s", field.Name, err)
+		return newFieldError(field, path, envName+"_*", "", err)
+	}
+
+	elemType := field.Type.Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	arr := reflect.MakeSlice(field.Type, len(indices), len(indices))
+	errs := []error{}
+	for _, i := range indices {
+		elem := reflect.New(elemType)
+		prefix := fmt.Sprintf("%s_%d_", envName, i)
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		err := p.parseStruct(elem.Elem(), prefix, elemPath)
+		if err != nil && failFast {
+			return err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if isPtr {
+			arr.Index(i).Set(elem)
+		} else {
+			arr.Index(i).Set(elem.Elem())
+		}
+	}
+
+	if len(errs) > 0 {
+		return joinErrs(errs)
+	}
+
+	value.Set(arr)
+	return nil
+}
+
+// structIndices finds the element indices of envName in keys, such as 0 and 1
+// from UPSTREAM_0_HOST and UPSTREAM_1_PORT. Indices must be contiguous from 0,
+// so none can be larger than the number of variables that have one.
+func structIndices(keys []string, envName string) ([]int, error) {
+	prefix := envName + "_"
+	found := map[int]string{}
+	numKeys := 0
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := key[len(prefix):]
+		end := strings.Index(rest, "_")
+		if end <= 0 {
+			continue
+		}
+
+		rawIndex := rest[:end]
+		index, err := strconv.Atoi(rawIndex)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("invalid index %q in variable [%s]", rawIndex, key)
+		}
+		if err != nil {
+			// Not an indexed variable, such as UPSTREAM_TIMEOUT_MS
+			continue
+		}
+		if index < 0 || strconv.Itoa(index) != rawIndex {
+			return nil, fmt.Errorf("invalid index %q in variable [%s]", rawIndex, key)
+		}
+		found[index] = key
+		numKeys++
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	indices := make([]int, 0, len(found))
+	for index := range found {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	last := indices[len(indices)-1]
+	if last > numKeys {
+		return nil, fmt.Errorf("index %d in variable [%s] is out of range: there are only %d indexed variables for [%s]", last, found[last], numKeys, envName)
+	}
+	for i, index := range indices {
+		switch {
+		case index == i:
+			continue
+		case index == i+1:
+			return nil, fmt.Errorf("missing index [%d] for variable [%s]", i, envName)
+		default:
+			return nil, fmt.Errorf("missing indices [%d-%d] for variable [%s]", i, index-1, envName)
+		}
+	}
+
+	return indices, nil
+}
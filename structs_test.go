@@ -0,0 +1,159 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_structSlices(t *testing.T) {
+	type Upstream struct {
+		Host string `env:"HOST" required:"true"`
+		Port int    `env:"PORT" default:"80"`
+	}
+
+	Convey("slice of structs", t, func() {
+		defer resetEnv(os.Environ())
+
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+
+		os.Setenv("UPSTREAM_0_HOST", "a.example.com")
+		os.Setenv("UPSTREAM_1_HOST", "b.example.com")
+		os.Setenv("UPSTREAM_1_PORT", "8080")
+		os.Setenv("UPSTREAM_TIMEOUT", "5s")
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Upstreams: []Upstream{
+				{Host: "a.example.com", Port: 80},
+				{Host: "b.example.com", Port: 8080},
+			},
+		}
+		prov := Provenance{}
+		err := Parse(actual, WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+		So(prov["Upstreams[1].Port"], ShouldEqual, "env")
+		So(prov["Upstreams[0].Port"], ShouldEqual, "default")
+	})
+
+	Convey("slice of struct pointers", t, func() {
+		type TestStruct struct {
+			Upstreams []*Upstream `env:"UPSTREAM"`
+		}
+
+		src := Map("test", map[string]string{
+			"UPSTREAM_0_HOST": "a.example.com",
+		})
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Upstreams: []*Upstream{
+				{Host: "a.example.com", Port: 80},
+			},
+		}
+		err := Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("no elements", t, func() {
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+		type RequiredStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM" required:"true"`
+		}
+
+		src := Map("test", map[string]string{})
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(src))
+		So(err, ShouldBeNil)
+		So(actual.Upstreams, ShouldBeNil)
+
+		err = Parse(&RequiredStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [UPSTREAM_0_*]")
+	})
+
+	Convey("gaps in indices", t, func() {
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+
+		src := Map("test", map[string]string{
+			"UPSTREAM_0_HOST": "a",
+			"UPSTREAM_0_PORT": "1",
+			"UPSTREAM_3_HOST": "d",
+			"UPSTREAM_3_PORT": "4",
+		})
+
+		err := Parse(&TestStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing indices [1-2] for variable [UPSTREAM]")
+
+		src = Map("test", map[string]string{
+			"UPSTREAM_0_HOST": "a",
+			"UPSTREAM_2_HOST": "c",
+		})
+		err = Parse(&TestStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing index [1] for variable [UPSTREAM]")
+	})
+
+	Convey("indices out of range", t, func() {
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+
+		tests := map[string]string{
+			"UPSTREAM_50000000_HOST":             "is out of range: there are only 2 indexed variables for [UPSTREAM]",
+			"UPSTREAM_99999999999999999999_HOST": `invalid index "99999999999999999999"`,
+		}
+		for key, expected := range tests {
+			src := Map("test", map[string]string{
+				"UPSTREAM_0_HOST": "a",
+				key:               "x",
+			})
+			err := Parse(&TestStruct{}, WithSource(src))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, expected)
+			So(len(err.Error()), ShouldBeLessThan, 300)
+		}
+	})
+
+	Convey("non-canonical index", t, func() {
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+
+		src := Map("test", map[string]string{
+			"UPSTREAM_01_HOST": "a",
+		})
+
+		err := Parse(&TestStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid index")
+	})
+
+	Convey("element errors", t, func() {
+		type TestStruct struct {
+			Upstreams []Upstream `env:"UPSTREAM"`
+		}
+
+		src := Map("test", map[string]string{
+			"UPSTREAM_0_PORT": "80",
+			"UPSTREAM_1_HOST": "b",
+			"UPSTREAM_1_PORT": "http",
+		})
+
+		err := Parse(&TestStruct{}, WithSource(src))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [UPSTREAM_0_HOST]")
+		So(err.Error(), ShouldContainSubstring, "invalid syntax")
+	})
+}
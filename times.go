@@ -0,0 +1,141 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	// Layouts that can be referred to by name in the layout tag
+	namedLayouts = map[string]string{
+		"ANSIC":       time.ANSIC,
+		"UnixDate":    time.UnixDate,
+		"RubyDate":    time.RubyDate,
+		"RFC822":      time.RFC822,
+		"RFC822Z":     time.RFC822Z,
+		"RFC850":      time.RFC850,
+		"RFC1123":     time.RFC1123,
+		"RFC1123Z":    time.RFC1123Z,
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"Kitchen":     time.Kitchen,
+		"DateTime":    "2006-01-02 15:04:05",
+		"DateOnly":    "2006-01-02",
+		"TimeOnly":    "15:04:05",
+	}
+)
+
+func handleTime(value reflect.Value, field reflect.StructField, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	t, err := parseTime(field, rawVal)
+	if err != nil {
+		return err
+	}
+	value.Set(reflect.ValueOf(t))
+	return nil
+}
+
+func parseTime(field reflect.StructField, rawVal string) (time.Time, error) {
+	layout := getTimeLayout(field)
+	t, err := time.Parse(layout, rawVal)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Get min/max values to check against
+	min, hasMin, err := getTimeTag(field, "min", layout)
+	if err != nil {
+		return time.Time{}, err
+	}
+	max, hasMax, err := getTimeTag(field, "max", layout)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if hasMin && t.Before(min) {
+		return time.Time{}, fmt.Errorf("%s must be at least %s", field.Name, min.Format(layout))
+	}
+	if hasMax && t.After(max) {
+		return time.Time{}, fmt.Errorf("%s must be no more than %s", field.Name, max.Format(layout))
+	}
+
+	return t, nil
+}
+
+// getTimeLayout returns the layout from the layout tag, which may be either a
+// layout string or the name of one of the layouts in the time package.
+// Defaults to RFC3339.
+func getTimeLayout(field reflect.StructField) string {
+	layout, exists := field.Tag.Lookup("layout")
+	if !exists || layout == "" {
+		return time.RFC3339
+	}
+	if named, isNamed := namedLayouts[layout]; isNamed {
+		return named
+	}
+	return layout
+}
+
+func getTimeTag(field reflect.StructField, tag, layout string) (time.Time, bool, error) {
+	rawVal, exists := field.Tag.Lookup(tag)
+	if !exists {
+		return time.Time{}, false, nil
+	}
+	parsedVal, err := time.Parse(layout, rawVal)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to parse tag %s on %s: %s", tag, field.Name, err)
+	}
+	return parsedVal, true, nil
+}
+
+func handleTimeSlice(value reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
+		return nil
+	}
+	arr := make([]time.Time, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
+		t, err := parseTime(field, raw)
+		arr[i] = t
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(arr))
+	return nil
+}
+
+func handleLocation(value reflect.Value, rawVal string) error {
+	if rawVal == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(rawVal)
+	if err != nil {
+		return err
+	}
+	value.Set(reflect.ValueOf(loc))
+	return nil
+}
+
+func handleLocationSlice(value reflect.Value, elems listElements) error {
+	if len(elems.raw) == 0 {
+		return nil
+	}
+	locs := make([]*time.Location, len(elems.raw), len(elems.raw))
+
+	err := elems.each(func(i int, str string) error {
+		loc, err := time.LoadLocation(str)
+		locs[i] = loc
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(locs))
+	return nil
+}
@@ -0,0 +1,126 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_times(t *testing.T) {
+	Convey("time with default layout", t, func() {
+		defer resetEnv(os.Environ())
+
+		type TestStruct struct {
+			Cutover time.Time `env:"CUTOVER"`
+		}
+
+		expectedTime, err := time.Parse(time.RFC3339, "2024-03-01T12:30:00Z")
+		So(err, ShouldBeNil)
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Cutover: expectedTime,
+		}
+
+		os.Setenv("CUTOVER", "2024-03-01T12:30:00Z")
+		err = Parse(actual)
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("time with custom and named layouts", t, func() {
+		type TestStruct struct {
+			Date  time.Time `env:"DATE" layout:"2006-01-02"`
+			Clock time.Time `env:"CLOCK" layout:"Kitchen"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"DATE":  "2024-03-01",
+			"CLOCK": "3:04PM",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Date, ShouldResemble, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		So(actual.Clock.Hour(), ShouldEqual, 15)
+		So(actual.Clock.Minute(), ShouldEqual, 4)
+	})
+
+	Convey("time min/max", t, func() {
+		type TestStruct struct {
+			Date time.Time `env:"DATE" layout:"2006-01-02" min:"2024-01-01" max:"2024-12-31"`
+		}
+
+		tests := map[string]bool{
+			"2023-12-31": false,
+			"2024-01-01": true,
+			"2024-06-15": true,
+			"2024-12-31": true,
+			"2025-01-01": false,
+			"not a date": false,
+		}
+
+		for value, pass := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"DATE": value})))
+			if pass {
+				So(err, ShouldBeNil)
+				So(actual.Date.Format("2006-01-02"), ShouldEqual, value)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+	})
+
+	Convey("bad time tag", t, func() {
+		type BadStruct struct {
+			Date time.Time `env:"DATE" layout:"2006-01-02" min:"01/01/2024"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"DATE": "2024-01-01"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag min")
+	})
+
+	Convey("time slice", t, func() {
+		type TestStruct struct {
+			Windows []time.Time `env:"WINDOWS" layout:"DateOnly"`
+		}
+
+		actual := &TestStruct{}
+		expected := &TestStruct{
+			Windows: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"WINDOWS": "2024-01-01, 2024-07-01"})))
+		So(err, ShouldBeNil)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("location", t, func() {
+		type TestStruct struct {
+			Zone  *time.Location   `env:"ZONE"`
+			Zones []*time.Location `env:"ZONES"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"ZONE":  "UTC",
+			"ZONES": "UTC, Local",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Zone, ShouldEqual, time.UTC)
+		So(actual.Zones, ShouldResemble, []*time.Location{time.UTC, time.Local})
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{
+			"ZONE":  "Not/AZone",
+			"ZONES": "UTC, Nowhere/Special",
+		})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Not/AZone")
+		So(err.Error(), ShouldContainSubstring, "Nowhere/Special")
+	})
+}
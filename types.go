@@ -2,15 +2,51 @@ package env
 
 import (
 	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/netip"
 	"net/url"
 	"reflect"
+	"regexp"
+	"text/template"
 	"time"
 	"unsafe"
 )
 
 var (
 	// Non-primitive types
-	urlType = reflect.TypeOf(url.URL{})
+	urlType      = reflect.TypeOf(url.URL{})
+	timeType     = reflect.TypeOf(time.Time{})
+	locationType = reflect.TypeOf(time.Location{})
+	ipType       = reflect.TypeOf(net.IP{})
+	ipNetType    = reflect.TypeOf(net.IPNet{})
+	addrType     = reflect.TypeOf(netip.Addr{})
+	prefixType   = reflect.TypeOf(netip.Prefix{})
+	addrPortType = reflect.TypeOf(netip.AddrPort{})
+	regexpType   = reflect.TypeOf(regexp.Regexp{})
+	templateType = reflect.TypeOf(template.Template{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+	levelType    = reflect.TypeOf(slog.Level(0))
+	formatType   = reflect.TypeOf(LogFormat(""))
+
+	// Struct types that are parsed from a single value instead of field by field
+	valueStructs = map[reflect.Type]bool{
+		urlType:      true,
+		timeType:     true,
+		locationType: true,
+		ipNetType:    true,
+		addrType:     true,
+		prefixType:   true,
+		addrPortType: true,
+		regexpType:   true,
+		templateType: true,
+		bigIntType:   true,
+		bigFloatType: true,
+		bigRatType:   true,
+	}
 
 	// Slice types
 	sliceOfStrings = reflect.TypeOf([]string{})
@@ -33,8 +69,18 @@ var (
 
 	sliceOfDurations = reflect.TypeOf([]time.Duration{})
 
+	sliceOfUrls        = reflect.TypeOf([]url.URL{})
 	sliceOfUrlPointers = reflect.TypeOf([]*url.URL{})
 
+	sliceOfTimes            = reflect.TypeOf([]time.Time{})
+	sliceOfLocationPointers = reflect.TypeOf([]*time.Location{})
+
+	sliceOfIPs           = reflect.TypeOf([]net.IP{})
+	sliceOfIPNetPointers = reflect.TypeOf([]*net.IPNet{})
+	sliceOfAddrs         = reflect.TypeOf([]netip.Addr{})
+	sliceOfPrefixes      = reflect.TypeOf([]netip.Prefix{})
+	sliceOfAddrPorts     = reflect.TypeOf([]netip.AddrPort{})
+
 	kindSizes = map[reflect.Kind]int{
 		reflect.Int8:  int(unsafe.Sizeof(int8(0)) * 8),
 		reflect.Int16: int(unsafe.Sizeof(int16(0)) * 8),
@@ -20,8 +20,11 @@ func handleUint(ref reflect.Value, structField reflect.StructField, rawVal strin
 
 func parseUint(field reflect.StructField, rawVal string) (uint64, error) {
 	size, err := getSize(field)
+	if err != nil {
+		return 0, err
+	}
 
-	i, err := strconv.ParseUint(rawVal, 10, size)
+	i, err := parseUintString(field, rawVal, size)
 	if err != nil {
 		return 0, err
 	}
@@ -43,13 +46,76 @@ func parseUint(field reflect.StructField, rawVal string) (uint64, error) {
 		return 0, fmt.Errorf("%s must be no more than %d", field.Name, max)
 	}
 
+	err = checkUintConstraints(field, i, size)
+	if err != nil {
+		return 0, err
+	}
+
 	return i, nil
 }
 
+// checkUintConstraints checks i against the exclusiveMin, exclusiveMax and
+// multipleOf tags, which have no effect unless they are set.
+func checkUintConstraints(field reflect.StructField, i uint64, size int) error {
+	if _, exists := field.Tag.Lookup("exclusiveMin"); exists {
+		exclusiveMin, err := getUintTag(field, "exclusiveMin", 0, size)
+		if err != nil {
+			return err
+		}
+		if i <= exclusiveMin {
+			return fmt.Errorf("%s must be greater than %d", field.Name, exclusiveMin)
+		}
+	}
+	if _, exists := field.Tag.Lookup("exclusiveMax"); exists {
+		exclusiveMax, err := getUintTag(field, "exclusiveMax", 0, size)
+		if err != nil {
+			return err
+		}
+		if i >= exclusiveMax {
+			return fmt.Errorf("%s must be less than %d", field.Name, exclusiveMax)
+		}
+	}
+	if _, exists := field.Tag.Lookup("multipleOf"); exists {
+		multipleOf, err := getUintTag(field, "multipleOf", 0, size)
+		if err != nil {
+			return err
+		}
+		if multipleOf == 0 {
+			return fmt.Errorf("unable to parse tag multipleOf on %s: must not be zero", field.Name)
+		}
+		if i%multipleOf != 0 {
+			return fmt.Errorf("%s must be a multiple of %d", field.Name, multipleOf)
+		}
+	}
+	return nil
+}
+
+// parseUintString parses an unsigned integer in the notation selected by the unit
+// and base tags.
+func parseUintString(field reflect.StructField, rawVal string, size int) (uint64, error) {
+	if hasIntUnit(field) {
+		n, err := parseIntUnits(field, rawVal)
+		if err != nil {
+			return 0, err
+		}
+		return bigToUint64(n, size)
+	}
+
+	base, err := getBase(field)
+	if err != nil {
+		return 0, err
+	}
+	digits, err := normalizeDigits(rawVal, base)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(digits, base, size)
+}
+
 func getUintTag(structField reflect.StructField, tag string, defaultVal uint64, size int) (uint64, error) {
 	rawVal, minExists := structField.Tag.Lookup(tag)
 	if minExists {
-		parsedVal, err := strconv.ParseUint(rawVal, 10, size)
+		parsedVal, err := parseUintString(structField, rawVal, size)
 		if err != nil {
 			return 0, fmt.Errorf("unable to parse tag %s on %s: %s", tag, structField.Name, err)
 		}
@@ -58,8 +124,8 @@ func getUintTag(structField reflect.StructField, tag string, defaultVal uint64,
 	return defaultVal, nil
 }
 
-func handleUintSlice(ref reflect.Value, structField reflect.StructField, rawArr []string) error {
-	if len(rawArr) == 0 {
+func handleUintSlice(ref reflect.Value, structField reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
 		return nil
 	}
 	var arr interface{}
@@ -68,15 +134,15 @@ func handleUintSlice(ref reflect.Value, structField reflect.StructField, rawArr
 	t := ref.Type()
 	switch t {
 	case sliceOfUint8s:
-		arr, err = getUint8Slice(structField, rawArr)
+		arr, err = getUint8Slice(structField, elems)
 	case sliceOfUint16s:
-		arr, err = getUint16Slice(structField, rawArr)
+		arr, err = getUint16Slice(structField, elems)
 	case sliceOfUint32s:
-		arr, err = getUint32Slice(structField, rawArr)
+		arr, err = getUint32Slice(structField, elems)
 	case sliceOfUint64s:
-		arr, err = getUint64Slice(structField, rawArr)
+		arr, err = getUint64Slice(structField, elems)
 	case sliceOfUints:
-		arr, err = getUintSlice(structField, rawArr)
+		arr, err = getUintSlice(structField, elems)
 	}
 
 	if err != nil {
@@ -87,62 +153,52 @@ func handleUintSlice(ref reflect.Value, structField reflect.StructField, rawArr
 	return nil
 }
 
-func getUint8Slice(structField reflect.StructField, split []string) ([]uint8, error) {
-	arr := make([]uint8, len(split), len(split))
-	for i, raw := range split {
+func getUint8Slice(structField reflect.StructField, elems listElements) ([]uint8, error) {
+	arr := make([]uint8, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseUint(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = uint8(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getUint16Slice(structField reflect.StructField, split []string) ([]uint16, error) {
-	arr := make([]uint16, len(split), len(split))
-	for i, raw := range split {
+func getUint16Slice(structField reflect.StructField, elems listElements) ([]uint16, error) {
+	arr := make([]uint16, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseUint(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = uint16(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getUint32Slice(structField reflect.StructField, split []string) ([]uint32, error) {
-	arr := make([]uint32, len(split), len(split))
-	for i, raw := range split {
+func getUint32Slice(structField reflect.StructField, elems listElements) ([]uint32, error) {
+	arr := make([]uint32, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseUint(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = uint32(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getUint64Slice(structField reflect.StructField, split []string) ([]uint64, error) {
-	arr := make([]uint64, len(split), len(split))
-	for i, raw := range split {
+func getUint64Slice(structField reflect.StructField, elems listElements) ([]uint64, error) {
+	arr := make([]uint64, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseUint(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = val
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
 
-func getUintSlice(structField reflect.StructField, split []string) ([]uint, error) {
-	arr := make([]uint, len(split), len(split))
-	for i, raw := range split {
+func getUintSlice(structField reflect.StructField, elems listElements) ([]uint, error) {
+	arr := make([]uint, len(elems.raw), len(elems.raw))
+	err := elems.each(func(i int, raw string) error {
 		val, err := parseUint(structField, raw)
-		if err != nil {
-			return arr, err
-		}
 		arr[i] = uint(val)
-	}
-	return arr, nil
+		return err
+	})
+	return arr, err
 }
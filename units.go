@@ -0,0 +1,166 @@
+package env
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Byte units are matched case-insensitively. Units ending in "iB" are powers of 1024
+	byteUnits = map[string]int64{
+		"":    1,
+		"b":   1,
+		"kb":  1000,
+		"mb":  1000 * 1000,
+		"gb":  1000 * 1000 * 1000,
+		"tb":  1000 * 1000 * 1000 * 1000,
+		"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+		"eb":  1000 * 1000 * 1000 * 1000 * 1000 * 1000,
+		"kib": 1 << 10,
+		"mib": 1 << 20,
+		"gib": 1 << 30,
+		"tib": 1 << 40,
+		"pib": 1 << 50,
+		"eib": 1 << 60,
+	}
+
+	// SI prefixes are case-sensitive so "m" (milli) isn't mistaken for "M" (mega)
+	siUnits = map[string]int64{
+		"":  1,
+		"k": 1000,
+		"K": 1000,
+		"M": 1000 * 1000,
+		"G": 1000 * 1000 * 1000,
+		"T": 1000 * 1000 * 1000 * 1000,
+		"P": 1000 * 1000 * 1000 * 1000 * 1000,
+		"E": 1000 * 1000 * 1000 * 1000 * 1000 * 1000,
+	}
+)
+
+// hasIntUnit reports whether an integer field has a unit tag, in which case it is
+// parsed by parseIntUnits.
+func hasIntUnit(field reflect.StructField) bool {
+	return field.Tag.Get("unit") != ""
+}
+
+// parseIntUnits parses a number followed by a unit suffix, such as 1.5GiB with
+// unit "bytes" or 10k with unit "si", into an exact integer. Fractional numbers
+// are allowed as long as the result is a whole number.
+func parseIntUnits(field reflect.StructField, rawVal string) (*big.Int, error) {
+	var units map[string]int64
+	var suffix string
+
+	num, rawSuffix := splitNumber(rawVal)
+	switch unit := field.Tag.Get("unit"); unit {
+	case "bytes":
+		units = byteUnits
+		suffix = strings.ToLower(rawSuffix)
+	case "si":
+		units = siUnits
+		suffix = rawSuffix
+	default:
+		return nil, fmt.Errorf("unsupported unit %q on %s", unit, field.Name)
+	}
+
+	multiplier, exists := units[suffix]
+	if !exists {
+		return nil, fmt.Errorf("unknown unit %q in %q", rawSuffix, rawVal)
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if num == "" || !ok {
+		return nil, fmt.Errorf("invalid number %q", rawVal)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(multiplier))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%q is not a whole number", rawVal)
+	}
+	return r.Num(), nil
+}
+
+func isPercent(field reflect.StructField) bool {
+	return field.Tag.Get("unit") == "percent"
+}
+
+// formatFloat formats a float bound for an error message. The bounds of percent
+// fields are shown as percentages, since that's how people usually write them.
+func formatFloat(field reflect.StructField, f float64, size int) string {
+	if !isPercent(field) {
+		return fmt.Sprintf("%f", f)
+	}
+	return formatPercent(f*100, size) + "%"
+}
+
+// ratioHint explains the value of a percent field that is out of bounds: what
+// it was read as, and if it looks like a percentage without the % sign, that it
+// was read as a ratio.
+func ratioHint(field reflect.StructField, rawVal string, f float64, size int) string {
+	if !isPercent(field) {
+		return ""
+	}
+	rawVal = strings.TrimSpace(rawVal)
+	if strings.HasSuffix(rawVal, "%") || f < 1 {
+		return fmt.Sprintf(", got %s%%", formatPercent(f*100, size))
+	}
+	return fmt.Sprintf(", got %s%% (numbers without %% are ratios: write %s%% or %s for %s percent)",
+		formatPercent(f*100, size), rawVal, formatPercent(f/100, size), rawVal)
+}
+
+// formatPercent formats f with no more digits than the field holds, so that
+// 0.29 shows as 29 rather than 28.999999999999996.
+func formatPercent(f float64, size int) string {
+	if size == 32 {
+		return strconv.FormatFloat(f, 'g', 6, 64)
+	}
+	return strconv.FormatFloat(f, 'g', 10, 64)
+}
+
+// parsePercent parses either a ratio such as 0.25 or a percentage such as 25%,
+// which is divided by 100. The division is exact, so 0.1% is as close to 0.001
+// as the float allows.
+func parsePercent(rawVal string, size int) (float64, error) {
+	num := strings.TrimSpace(strings.TrimSuffix(rawVal, "%"))
+	r, ok := new(big.Rat).SetString(num)
+	if num == "" || !ok || strings.Contains(num, "/") {
+		return 0, fmt.Errorf("invalid percentage %q", rawVal)
+	}
+	if strings.HasSuffix(rawVal, "%") {
+		r.Quo(r, big.NewRat(100, 1))
+	}
+
+	if size == 32 {
+		f, _ := r.Float32()
+		return float64(f), nil
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
+
+// splitNumber splits a leading decimal number from the unit that follows it.
+// Whitespace between the two is ignored.
+func splitNumber(rawVal string) (string, string) {
+	end := 0
+	for end < len(rawVal) && strings.IndexByte("+-.0123456789", rawVal[end]) >= 0 {
+		end++
+	}
+	return rawVal[:end], strings.TrimSpace(rawVal[end:])
+}
+
+// bigToInt64 converts n to an int64, checking that it fits in size bits.
+func bigToInt64(n *big.Int, size int) (int64, error) {
+	if !n.IsInt64() || n.Int64() < minInts[size] || n.Int64() > maxInts[size] {
+		return 0, fmt.Errorf("%s overflows a %d-bit integer", n, size)
+	}
+	return n.Int64(), nil
+}
+
+// bigToUint64 converts n to a uint64, checking that it fits in size bits.
+func bigToUint64(n *big.Int, size int) (uint64, error) {
+	if !n.IsUint64() || n.Uint64() > maxUints[size] {
+		return 0, fmt.Errorf("%s overflows a %d-bit unsigned integer", n, size)
+	}
+	return n.Uint64(), nil
+}
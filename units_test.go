@@ -0,0 +1,257 @@
+package env
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse_units(t *testing.T) {
+	Convey("bytes", t, func() {
+		type TestStruct struct {
+			Size int64 `env:"SIZE" unit:"bytes"`
+		}
+
+		tests := map[string]int64{
+			"512":     512,
+			"512B":    512,
+			"512KiB":  512 * 1024,
+			"10MB":    10 * 1000 * 1000,
+			"10 mb":   10 * 1000 * 1000,
+			"1.5GiB":  1536 * 1024 * 1024,
+			"2TiB":    2 << 40,
+			"1kb":     1000,
+			"-1KiB":   -1024,
+			"0.5KiB":  512,
+			"7EiB":    7 << 60,
+			"1.25 kB": 1250,
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"SIZE": value})))
+			So(err, ShouldBeNil)
+			So(actual.Size, ShouldEqual, expected)
+		}
+	})
+
+	Convey("si", t, func() {
+		type TestStruct struct {
+			Count uint32 `env:"COUNT" unit:"si"`
+		}
+
+		tests := map[string]uint32{
+			"10":   10,
+			"10k":  10000,
+			"10K":  10000,
+			"2M":   2000000,
+			"1.5G": 1500000000,
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"COUNT": value})))
+			So(err, ShouldBeNil)
+			So(actual.Count, ShouldEqual, expected)
+		}
+	})
+
+	Convey("invalid values", t, func() {
+		type TestStruct struct {
+			Size  uint16 `env:"SIZE" unit:"bytes"`
+			Count int8   `env:"COUNT" unit:"si"`
+		}
+
+		tests := map[string]string{
+			"SIZE":  "64KiB",
+			"COUNT": "1k",
+		}
+		for name, value := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "overflows")
+		}
+
+		invalid := map[string]string{
+			"SIZE":  "1.5B",
+			"COUNT": "1m",
+		}
+		for name, value := range invalid {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+			So(err, ShouldNotBeNil)
+		}
+
+		for _, value := range []string{"KiB", "1XB", "1..5KiB", "-1KiB", "1e3"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"SIZE": value})))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("min/max", t, func() {
+		type TestStruct struct {
+			Size uint64 `env:"SIZE" unit:"bytes" min:"1KiB" max:"1.5MiB"`
+		}
+
+		tests := map[string]bool{
+			"1023":     false,
+			"1KiB":     true,
+			"1MB":      true,
+			"1.5MiB":   true,
+			"1572865B": false,
+			"2MiB":     false,
+		}
+
+		for value, pass := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"SIZE": value})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+	})
+
+	Convey("slices", t, func() {
+		type TestStruct struct {
+			Sizes []int `env:"SIZES" unit:"bytes"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"SIZES": "1KiB, 2KB, 3"})))
+		So(err, ShouldBeNil)
+		So(actual.Sizes, ShouldResemble, []int{1024, 2000, 3})
+	})
+
+	Convey("unsupported unit", t, func() {
+		type BadStruct struct {
+			Size int `env:"SIZE" unit:"furlongs"`
+		}
+
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"SIZE": "1"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported unit")
+	})
+}
+
+func TestParse_percent(t *testing.T) {
+	Convey("percentages and ratios", t, func() {
+		type TestStruct struct {
+			Rate  float64 `env:"RATE" unit:"percent"`
+			Small float32 `env:"SMALL" unit:"percent"`
+		}
+
+		tests := map[string]float64{
+			"0.25":   0.25,
+			"25%":    0.25,
+			"25 %":   0.25,
+			"12.5%":  0.125,
+			"0.1%":   0.001,
+			"100%":   1,
+			"0":      0,
+			"0%":     0,
+			"1":      1,
+			"2.5e-1": 0.25,
+		}
+
+		for value, expected := range tests {
+			actual := &TestStruct{}
+			err := Parse(actual, WithSource(Map("test", map[string]string{"RATE": value})))
+			So(err, ShouldBeNil)
+			So(actual.Rate, ShouldEqual, expected)
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"SMALL": "33%"})))
+		So(err, ShouldBeNil)
+		So(actual.Small, ShouldEqual, float32(0.33))
+	})
+
+	Convey("default range of 0 to 1", t, func() {
+		type TestStruct struct {
+			Rate float64 `env:"RATE" unit:"percent"`
+		}
+
+		for _, value := range []string{"25", "101%", "-1%", "-0.5"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": value})))
+			So(err, ShouldNotBeNil)
+		}
+
+		tests := map[string]string{
+			"25":   "Rate must be no more than 100%, got 2500% (numbers without % are ratios: write 25% or 0.25 for 25 percent)",
+			"101%": "Rate must be no more than 100%, got 101%",
+			"-0.5": "Rate must be at least 0%, got -50%",
+		}
+		for value, expected := range tests {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, expected)
+		}
+
+		for _, value := range []string{"%", "abc%", "1/4", "NaN", "25%%"} {
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid percentage")
+		}
+	})
+
+	Convey("bounds are normalized too", t, func() {
+		type TestStruct struct {
+			Rollout []float64 `env:"ROLLOUT" unit:"percent" min:"5%" exclusiveMax:"0.5" multipleOf:"5%"`
+			Growth  float64   `env:"GROWTH" unit:"percent" max:"250%"`
+			Canary  []float32 `env:"CANARY" unit:"percent" multipleOf:"5%"`
+		}
+
+		tests := map[string]bool{
+			"CANARY=45%,35%":     true,
+			"CANARY=0.15,95%":    true,
+			"CANARY=45%,36%":     false,
+			"ROLLOUT=5%,0.1,45%": true,
+			"ROLLOUT=0.05,0.5":   false,
+			"ROLLOUT=1%":         false,
+			"ROLLOUT=12%":        false,
+			"GROWTH=150%":        true,
+			"GROWTH=2.5":         true,
+			"GROWTH=251%":        false,
+		}
+
+		for keyVal, pass := range tests {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			if pass {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+			}
+		}
+
+		messages := map[string]string{
+			"ROLLOUT=0.5":  "Rollout must be less than 50%, got 50%",
+			"ROLLOUT=12%":  "Rollout must be a multiple of 5%",
+			"CANARY=0.29":  "Canary must be a multiple of 5%",
+			"GROWTH=2.9":   "Growth must be no more than 250%, got 290% (numbers without % are ratios: write 2.9% or 0.029 for 2.9 percent)",
+			"ROLLOUT=0.01": "Rollout must be at least 5%, got 1%",
+		}
+		for keyVal, expected := range messages {
+			split := strings.SplitN(keyVal, "=", 2)
+			err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{split[0]: split[1]})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, expected)
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"ROLLOUT": "5%, 10%, 0.25"})))
+		So(err, ShouldBeNil)
+		So(actual.Rollout, ShouldResemble, []float64{0.05, 0.1, 0.25})
+	})
+
+	Convey("unsupported float unit", t, func() {
+		type TestStruct struct {
+			Rate float64 `env:"RATE" unit:"permille"`
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"RATE": "1"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unsupported unit")
+	})
+}
@@ -1,44 +1,110 @@
 package env
 
 import (
+	"fmt"
 	"net/url"
 	"reflect"
-
-	"github.com/pcman312/errutils"
+	"strings"
 )
 
-func handleUrl(ref reflect.Value, rawVal string) error {
+func handleUrl(ref reflect.Value, field reflect.StructField, rawVal string) error {
 	if rawVal == "" {
 		return nil
 	}
-	u, err := url.Parse(rawVal)
+	u, err := parseUrl(field, rawVal)
 	if err != nil {
 		return err
 	}
-	ref.Set(reflect.ValueOf(u))
+	if ref.Kind() == reflect.Ptr {
+		ref.Set(reflect.ValueOf(u))
+	} else {
+		ref.Set(reflect.ValueOf(*u))
+	}
 	return nil
 }
 
-func handleUrlSlice(ref reflect.Value, rawArr []string) error {
-	if len(rawArr) == 0 {
-		return nil
+// parseUrl parses rawVal and checks it against the absolute, schemes, userinfo
+// and requirePort tags. Errors don't include the URL since it may contain a
+// password.
+func parseUrl(field reflect.StructField, rawVal string) (*url.URL, error) {
+	u, err := url.Parse(rawVal)
+	if err != nil {
+		// A *url.Error repeats the URL, so only keep the reason
+		if urlErr, ok := err.(*url.Error); ok {
+			return nil, fmt.Errorf("unable to parse URL: %s", urlErr.Err)
+		}
+		return nil, err
 	}
-	urls := make([]*url.URL, 0, len(rawArr))
-	errs := []error{}
 
-	for _, str := range rawArr {
-		url, err := url.Parse(str)
-		if err != nil {
-			errs = append(errs, err)
-			continue
+	absolute, err := getBoolTag(field, "absolute", false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tag absolute on %s: %s", field.Name, err)
+	}
+	if absolute && (!u.IsAbs() || u.Host == "") {
+		return nil, fmt.Errorf("%s must be an absolute URL with a scheme and host", field.Name)
+	}
+
+	if rawSchemes, exists := field.Tag.Lookup("schemes"); exists {
+		schemes := strings.Split(rawSchemes, ",")
+		allowed := false
+		for i, scheme := range schemes {
+			schemes[i] = strings.ToLower(strings.TrimSpace(scheme))
+			if schemes[i] == u.Scheme {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%s must use one of the schemes [%s]", field.Name, strings.Join(schemes, ", "))
+		}
+	}
+
+	switch userinfo := field.Tag.Get("userinfo"); userinfo {
+	case "":
+	case "required":
+		if u.User == nil {
+			return nil, fmt.Errorf("%s must include user info", field.Name)
 		}
-		urls = append(urls, url)
+	case "forbidden":
+		if u.User != nil {
+			return nil, fmt.Errorf("%s must not include user info", field.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unable to parse tag userinfo on %s: must be either required or forbidden", field.Name)
+	}
+
+	requirePort, err := getBoolTag(field, "requirePort", false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tag requirePort on %s: %s", field.Name, err)
+	}
+	if requirePort && u.Port() == "" {
+		return nil, fmt.Errorf("%s must include a port", field.Name)
+	}
+
+	return u, nil
+}
+
+func handleUrlSlice(ref reflect.Value, field reflect.StructField, elems listElements) error {
+	if len(elems.raw) == 0 {
+		return nil
 	}
+	urls := reflect.MakeSlice(ref.Type(), len(elems.raw), len(elems.raw))
 
-	if len(errs) > 0 {
-		return errutils.JoinErrs(", ", errs...)
+	err := elems.each(func(i int, str string) error {
+		u, err := parseUrl(field, str)
+		if err != nil {
+			return err
+		}
+		if ref.Type() == sliceOfUrlPointers {
+			urls.Index(i).Set(reflect.ValueOf(u))
+		} else {
+			urls.Index(i).Set(reflect.ValueOf(*u))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	ref.Set(reflect.ValueOf(urls))
+	ref.Set(urls)
 	return nil
 }
@@ -53,3 +53,120 @@ func TestParse_urls(t *testing.T) {
 		So(actual, ShouldResemble, expected)
 	})
 }
+
+func TestParse_urlRules(t *testing.T) {
+	Convey("url values", t, func() {
+		type TestStruct struct {
+			URL  url.URL   `env:"URL"`
+			URLs []url.URL `env:"URLS"`
+		}
+
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"URL":  "https://example.com/path",
+			"URLS": "https://a.example.com, https://b.example.com",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.URL.String(), ShouldEqual, "https://example.com/path")
+		So(actual.URLs, ShouldHaveLength, 2)
+		So(actual.URLs[1].Host, ShouldEqual, "b.example.com")
+	})
+
+	Convey("rules", t, func() {
+		type TestStruct struct {
+			Absolute  *url.URL   `env:"ABSOLUTE" absolute:"true"`
+			Schemes   url.URL    `env:"SCHEMES" schemes:"https, postgres"`
+			User      *url.URL   `env:"USER" userinfo:"required"`
+			NoUser    []*url.URL `env:"NOUSER" userinfo:"forbidden"`
+			Port      *url.URL   `env:"PORT" requirePort:"true"`
+			NoSchemes []url.URL  `env:"NOSCHEMES" schemes:"https"`
+		}
+
+		tests := map[string]map[string]bool{
+			"ABSOLUTE": {
+				"https://example.com": true,
+				"/relative/path":      false,
+				"file:///etc/hosts":   false,
+				"example.com":         false,
+			},
+			"SCHEMES": {
+				"https://example.com":    true,
+				"postgres://db/name":     true,
+				"HTTPS://example.com":    true,
+				"http://example.com":     false,
+				"//example.com/relative": false,
+			},
+			"USER": {
+				"postgres://admin:pw@db/name": true,
+				"postgres://db/name":          false,
+			},
+			"NOUSER": {
+				"https://a.example.com, https://b.example.com":         true,
+				"https://a.example.com, https://user:pw@b.example.com": false,
+			},
+			"PORT": {
+				"https://example.com:8443": true,
+				"https://example.com":      false,
+			},
+			"NOSCHEMES": {
+				"https://a, https://b": true,
+				"https://a, ftp://b":   false,
+			},
+		}
+
+		for name, values := range tests {
+			for value, pass := range values {
+				err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{name: value})))
+				if pass {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+				}
+			}
+		}
+	})
+
+	Convey("errors do not include the url", t, func() {
+		type TestStruct struct {
+			URL *url.URL `env:"URL" schemes:"https"`
+		}
+
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"URL": "http://admin:hunter2@db"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "must use one of the schemes [https]")
+		So(err.Error(), ShouldNotContainSubstring, "hunter2")
+
+		for _, value := range []string{"http://admin:hunter2@db:abc", "http://admin:hunter2@db:abc/path"} {
+			err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"URL": value})))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "admin:xxxxx@db:abc")
+			So(err.Error(), ShouldNotContainSubstring, "hunter2")
+		}
+
+		type SliceStruct struct {
+			URLs []*url.URL `env:"URLS"`
+		}
+		err = Parse(&SliceStruct{}, WithSource(Map("test", map[string]string{"URLS": "http://db, http://admin:hunter2@db:abc"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldNotContainSubstring, "hunter2")
+	})
+
+	Convey("bad tags", t, func() {
+		type BadUserinfo struct {
+			URL *url.URL `env:"URL" userinfo:"maybe"`
+		}
+		type BadAbsolute struct {
+			URL *url.URL `env:"URL" absolute:"yes please"`
+		}
+
+		src := WithSource(Map("test", map[string]string{"URL": "https://example.com"}))
+
+		err := Parse(&BadUserinfo{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag userinfo")
+
+		err = Parse(&BadAbsolute{}, src)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unable to parse tag absolute")
+	})
+}
@@ -0,0 +1,137 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	variantsLock sync.RWMutex
+	// Registered implementations by interface type, then by name
+	variants = map[reflect.Type]map[string]reflect.Type{}
+)
+
+// Register makes the type of impl available under name to interface fields of
+// type I that have a discriminator tag. impl must be a struct or a pointer to a
+// struct, and the field is set to a value of the same type:
+//
+//	env.Register[Cache]("redis", &RedisCache{})
+//
+// Register panics if impl is not a struct or name is already registered for I,
+// so it is usually called from an init function.
+func Register[I any](name string, impl I) {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("env: cannot register %q for %s: not an interface", name, iface))
+	}
+	t := reflect.TypeOf(impl)
+	if t == nil || structType(t) == nil {
+		panic(fmt.Sprintf("env: cannot register %q for %s: %v is not a struct or a pointer to a struct", name, iface, t))
+	}
+	if name == "" {
+		panic(fmt.Sprintf("env: cannot register an empty name for %s", iface))
+	}
+
+	variantsLock.Lock()
+	defer variantsLock.Unlock()
+	if variants[iface] == nil {
+		variants[iface] = map[string]reflect.Type{}
+	}
+	if _, exists := variants[iface][name]; exists {
+		panic(fmt.Sprintf("env: %q is already registered for %s", name, iface))
+	}
+	variants[iface][name] = t
+}
+
+// structType returns the struct type of t if t is a struct or a pointer to one.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// lookupVariant returns the type registered under name for iface, or nil and
+// the sorted names that are registered.
+func lookupVariant(iface reflect.Type, name string) (reflect.Type, []string) {
+	variantsLock.RLock()
+	t, exists := variants[iface][name]
+	variantsLock.RUnlock()
+
+	if exists {
+		return t, nil
+	}
+	return nil, variantNames(iface)
+}
+
+// variantNames returns the sorted names registered for iface.
+func variantNames(iface reflect.Type) []string {
+	variantsLock.RLock()
+	defer variantsLock.RUnlock()
+
+	names := make([]string, 0, len(variants[iface]))
+	for registered := range variants[iface] {
+		names = append(names, registered)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handleVariant populates an interface field with a registered implementation.
+// For a field tagged env:"CACHE" discriminator:"BACKEND", the implementation is
+// named by CACHE_BACKEND, and if that is redis the implementation is parsed with
+// the prefix CACHE_REDIS_. The required and default tags apply to the
+// discriminator variable.
+func (p *parser) handleVariant(value reflect.Value, field reflect.StructField, envName, path string, rules valueRules) error {
+	discriminator := strings.TrimSpace(field.Tag.Get("discriminator"))
+	if discriminator == "" {
+		err := fmt.Errorf("interface field %s requires a discriminator tag", field.Name)
+		return newFieldError(field, path, envName, "", err)
+	}
+	discriminatorVar := envName + "_" + discriminator
+	err := p.vars.bind(discriminatorVar, path, field)
+	if err != nil {
+		return newFieldError(field, path, discriminatorVar, "", err)
+	}
+
+	name, origin, err := p.getFieldValue(discriminatorVar, rules)
+	if err != nil {
+		return newFieldError(field, path, discriminatorVar, "", err)
+	}
+	if name == "" {
+		if origin != "" {
+			value.Set(reflect.Zero(value.Type()))
+		}
+		return nil
+	}
+
+	t, names := lookupVariant(field.Type, name)
+	if t == nil {
+		err = fmt.Errorf("invalid value %q in %s: must be one of [%s]", name, describeVar(discriminatorVar, origin), strings.Join(names, ", "))
+		return newFieldError(field, path, discriminatorVar, name, err)
+	}
+
+	impl := reflect.New(structType(t))
+	prefix := envName + "_" + strings.ToUpper(name) + "_"
+	err = p.parseStruct(impl.Elem(), prefix, path)
+	if err != nil {
+		return err
+	}
+
+	if t.Kind() == reflect.Ptr {
+		value.Set(impl)
+	} else {
+		value.Set(impl.Elem())
+	}
+
+	if p.provenance != nil {
+		p.provenance[path] = origin
+	}
+	return nil
+}
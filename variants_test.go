@@ -0,0 +1,115 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testCache interface {
+	Backend() string
+}
+
+type testMemoryCache struct {
+	Size int `env:"SIZE" default:"100"`
+}
+
+func (c testMemoryCache) Backend() string { return "memory" }
+
+type testRedisCache struct {
+	Addr    string        `env:"ADDR" required:"true"`
+	Timeout time.Duration `env:"TIMEOUT" default:"1s"`
+}
+
+func (c *testRedisCache) Backend() string { return "redis" }
+
+func init() {
+	Register[testCache]("memory", testMemoryCache{})
+	Register[testCache]("redis", &testRedisCache{})
+}
+
+func TestParse_variants(t *testing.T) {
+	type TestStruct struct {
+		Cache testCache `env:"CACHE" discriminator:"BACKEND"`
+	}
+
+	Convey("selects the registered implementation", t, func() {
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{
+			"CACHE_BACKEND":     "redis",
+			"CACHE_REDIS_ADDR":  "localhost:6379",
+			"CACHE_MEMORY_SIZE": "5",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Cache, ShouldResemble, &testRedisCache{Addr: "localhost:6379", Timeout: time.Second})
+
+		actual = &TestStruct{}
+		err = Parse(actual, WithSource(Map("test", map[string]string{
+			"CACHE_BACKEND":     "memory",
+			"CACHE_MEMORY_SIZE": "5",
+		})))
+		So(err, ShouldBeNil)
+		So(actual.Cache, ShouldResemble, testMemoryCache{Size: 5})
+	})
+
+	Convey("only the chosen implementation's variables are required", t, func() {
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{"CACHE_BACKEND": "memory"})))
+		So(err, ShouldBeNil)
+		So(actual.Cache, ShouldResemble, testMemoryCache{Size: 100})
+
+		err = Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"CACHE_BACKEND": "redis"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [CACHE_REDIS_ADDR]")
+	})
+
+	Convey("unset discriminator", t, func() {
+		actual := &TestStruct{}
+		err := Parse(actual, WithSource(Map("test", map[string]string{})))
+		So(err, ShouldBeNil)
+		So(actual.Cache, ShouldBeNil)
+
+		type RequiredStruct struct {
+			Cache testCache `env:"CACHE" discriminator:"BACKEND" required:"true"`
+		}
+		err = Parse(&RequiredStruct{}, WithSource(Map("test", map[string]string{})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "missing required variable [CACHE_BACKEND]")
+
+		type DefaultStruct struct {
+			Cache testCache `env:"CACHE" discriminator:"BACKEND" default:"memory"`
+		}
+		defaulted := &DefaultStruct{}
+		prov := Provenance{}
+		err = Parse(defaulted, WithSource(Map("test", map[string]string{})), WithProvenance(prov))
+		So(err, ShouldBeNil)
+		So(defaulted.Cache, ShouldResemble, testMemoryCache{Size: 100})
+		So(prov["Cache"], ShouldEqual, "default")
+		So(prov["Cache.Size"], ShouldEqual, "default")
+	})
+
+	Convey("unknown implementation", t, func() {
+		err := Parse(&TestStruct{}, WithSource(Map("test", map[string]string{"CACHE_BACKEND": "memcached"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `invalid value "memcached" in variable [CACHE_BACKEND]: must be one of [memory, redis]`)
+	})
+
+	Convey("missing discriminator tag", t, func() {
+		type BadStruct struct {
+			Cache testCache `env:"CACHE"`
+		}
+		err := Parse(&BadStruct{}, WithSource(Map("test", map[string]string{"CACHE": "memory"})))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "requires a discriminator tag")
+	})
+}
+
+func TestRegister(t *testing.T) {
+	Convey("invalid registrations panic", t, func() {
+		So(func() { Register[testCache]("memory", testMemoryCache{}) }, ShouldPanic)
+		So(func() { Register[testCache]("", testMemoryCache{}) }, ShouldPanic)
+		So(func() { Register[testCache]("nil", nil) }, ShouldPanic)
+		So(func() { Register[string]("string", "value") }, ShouldPanic)
+	})
+}